@@ -0,0 +1,5 @@
+data "relyt_dps_list" "dps_list" {
+  dwsu_id = "dwsuId"
+  engine  = "extreme"
+  status  = "READY"
+}
@@ -0,0 +1,6 @@
+data "relyt_dps_specs" "specs" {
+  edition = "standard"
+  engine  = "extreme"
+  cloud   = "cloudID"
+  region  = "regionID"
+}
@@ -0,0 +1,3 @@
+data "relyt_dwsu" "dwsu" {
+  domain = "domain"
+}
@@ -1,3 +1,7 @@
 
 data "relyt_dwsus" "dwsus" {
-}
\ No newline at end of file
+  cloud  = "cloudID"
+  region = "regionID"
+  status = "READY"
+  tags   = ["production"]
+}
@@ -0,0 +1,4 @@
+data "relyt_dwusers" "dwusers" {
+  dwsu_id     = "dwsuId"
+  name_prefix = "analyst"
+}
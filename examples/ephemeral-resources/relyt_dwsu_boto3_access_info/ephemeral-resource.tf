@@ -0,0 +1,9 @@
+ephemeral "relyt_dwsu_boto3_access_info" "boto3" {
+  dwsu_id    = "dwsu-id-from-an-duws-resource"
+  account_id = "account-id"
+}
+
+provider "aws" {
+  access_key = ephemeral.relyt_dwsu_boto3_access_info.boto3.boto3_access_infos[0].access_key
+  secret_key = ephemeral.relyt_dwsu_boto3_access_info.boto3.boto3_access_infos[0].secret_key
+}
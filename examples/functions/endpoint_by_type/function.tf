@@ -0,0 +1,3 @@
+output "database_host" {
+  value = provider::relyt::endpoint_by_type(data.relyt_dwsu.dwsu.endpoints, "database").host
+}
@@ -0,0 +1,4 @@
+import {
+  to = relyt_dwsu_external_schema.schema
+  id = provider::relyt::external_schema_id("database", "catalog", "schema")
+}
@@ -0,0 +1,3 @@
+output "jdbc_url" {
+  value = provider::relyt::jdbc_url(relyt_dwsu.dwsu.database_endpoint, "dev")
+}
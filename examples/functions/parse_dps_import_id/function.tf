@@ -0,0 +1,7 @@
+locals {
+  dps = provider::relyt::parse_dps_import_id("dwsu_id,dps_id")
+}
+
+output "dps_id" {
+  value = local.dps.dps_id
+}
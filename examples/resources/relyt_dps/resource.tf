@@ -1,9 +1,10 @@
-
-
 resource "relyt_dps" "abc" {
   dwsu_id     = "dwsu-id-from-an-duws-resource"
   name        = "edps-exp"
   description = "An EDPS Example" #optional
   engine      = "extreme"
   size        = "S"
-}
\ No newline at end of file
+
+  auto_suspend = true #optional
+  auto_resume  = true #optional
+}
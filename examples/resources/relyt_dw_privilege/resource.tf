@@ -0,0 +1,15 @@
+resource "relyt_dw_privilege" "sales_connect" {
+  grantee     = relyt_dw_role.analyst.name
+  object_type = "database"
+  database    = "sales"
+  privileges  = ["CONNECT"]
+}
+
+resource "relyt_dw_privilege" "orders_select" {
+  grantee     = relyt_dw_role.analyst.name
+  object_type = "table"
+  database    = "sales"
+  schema      = "public"
+  table       = "orders"
+  privileges  = ["SELECT"]
+}
@@ -0,0 +1,5 @@
+resource "relyt_dw_role" "analyst" {
+  dwsu_id     = relyt_dwsu.dwsu.id
+  name        = "analyst"
+  description = "read only access to the sales database"
+}
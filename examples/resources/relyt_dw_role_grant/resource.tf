@@ -0,0 +1,5 @@
+resource "relyt_dw_role_grant" "analyst" {
+  dwsu_id      = relyt_dwsu.dwsu.id
+  role         = relyt_dw_role.analyst.name
+  account_name = relyt_dwuser.user.account_name
+}
@@ -6,4 +6,22 @@ resource "relyt_dwuser" "user1" {
   datalake_aws_lakeformation_role_arn      = "role arn arn=//xxxx"
   async_query_result_location_prefix       = "s3=//bucket-name/prefix/..."
   async_query_result_location_aws_role_arn = "role arn arn=//xxxx"
-}
\ No newline at end of file
+}
+
+# keep the password out of state, bump the version to reset it
+resource "relyt_dwuser" "user2" {
+  dwsu_id                     = "dwsu-id-from-an-dwsu-resource"
+  account_name                = "UniqueAccountName2"
+  account_password_wo         = var.user2_password
+  account_password_wo_version = 1
+}
+
+# generate the password and rotate it every 90 days
+resource "relyt_dwuser" "user3" {
+  dwsu_id      = "dwsu-id-from-an-dwsu-resource"
+  account_name = "UniqueAccountName3"
+  password_generator = {
+    length       = 20
+    rotate_after = "2160h"
+  }
+}
@@ -4,12 +4,20 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/http"
 	"net/url"
 	"strconv"
 )
 
 func NewRelytClient(config RelytClientConfig) (RelytClient, error) {
-	return RelytClient{config}, nil
+	transport := newHttpTransport()
+	if config.RelytDatabaseClientConfig != nil {
+		//database client 由各资源按需创建，通过config共享同一个连接池
+		databaseClientConfig := *config.RelytDatabaseClientConfig
+		databaseClientConfig.transport = transport
+		config.RelytDatabaseClientConfig = &databaseClientConfig
+	}
+	return RelytClient{config, newHttpClient(transport, config.ClientTimeout)}, nil
 }
 
 type RelytClientConfig struct {
@@ -20,11 +28,15 @@ type RelytClientConfig struct {
 	CheckTimeOut              int64                      `json:"checkTimeOut"`
 	CheckInterval             int32                      `json:"checkInterval"`
 	ClientTimeout             int32                      `json:"clientTimeout"`
+	DeletionProtection        bool                       `json:"deletionProtection"`
 	RelytDatabaseClientConfig *RelytDatabaseClientConfig `json:"relytDatabaseClientConfig"`
+	LogRedactConfig
+	RetryConfig
 }
 
 type RelytClient struct {
 	RelytClientConfig
+	httpClient *http.Client
 }
 
 func (p *RelytClient) ListDwsu(ctx context.Context, pageSize, pageNumber int) ([]*DwsuModel, error) {
@@ -53,11 +65,10 @@ func (p *RelytClient) CreateDwsu(ctx context.Context, request DwsuModel) (*Commo
 func (p *RelytClient) GetDwsu(ctx context.Context, dwServiceUnitId string) (*DwsuModel, error) {
 	path := fmt.Sprintf("/dwsu/%s", dwServiceUnitId)
 	resp := CommonRelytResponse[DwsuModel]{}
-	handler := func(response *CommonRelytResponse[DwsuModel], respString []byte) (*CommonRelytResponse[DwsuModel], error) {
-		if response.Code != CODE_SUCCESS && resp.Code != CODE_DWSU_NOT_FOUND {
-			body := string(respString)
-			tflog.Error(ctx, "error call api! resp code not success! body: "+body)
-			return response, fmt.Errorf(body)
+	handler := func(response *CommonRelytResponse[DwsuModel], apiErr *APIError) (*CommonRelytResponse[DwsuModel], error) {
+		if apiErr != nil && !IsNotFound(apiErr) {
+			tflog.Error(ctx, "error call api! resp code not success! "+apiErr.Error())
+			return response, apiErr
 		}
 		return response, nil
 	}
@@ -69,14 +80,22 @@ func (p *RelytClient) GetDwsu(ctx context.Context, dwServiceUnitId string) (*Dws
 	return resp.Data, nil
 }
 
+func (p *RelytClient) PatchDwsu(ctx context.Context, dwServiceUnitId string, patch DwsuPatch) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s", dwServiceUnitId)
+	resp := CommonRelytResponse[string]{}
+	if err := doHttpRequest(p, ctx, "", path, "PATCH", &resp, patch, nil, nil); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (p *RelytClient) DropDwsu(ctx context.Context, dwServiceUnitId string) error {
 	path := fmt.Sprintf("/dwsu/%s", dwServiceUnitId)
 	resp := CommonRelytResponse[string]{}
-	handler := func(response *CommonRelytResponse[string], respString []byte) (*CommonRelytResponse[string], error) {
-		if response.Code != CODE_SUCCESS && resp.Code != CODE_DWSU_NOT_FOUND {
-			body := string(respString)
-			tflog.Error(ctx, "error call api! resp code not success! body: "+body)
-			return response, fmt.Errorf(body)
+	handler := func(response *CommonRelytResponse[string], apiErr *APIError) (*CommonRelytResponse[string], error) {
+		if apiErr != nil && !IsNotFound(apiErr) {
+			tflog.Error(ctx, "error call api! resp code not success! "+apiErr.Error())
+			return response, apiErr
 		}
 		return response, nil
 	}
@@ -99,6 +118,9 @@ func (p *RelytClient) ListDps(ctx context.Context, pageSize, pageNumber int, dwS
 	if err != nil {
 		return nil, err
 	}
+	if resp.Data == nil {
+		return nil, nil
+	}
 	return resp.Data.Records, nil
 }
 
@@ -120,6 +142,33 @@ func (p *RelytClient) PatchDps(ctx context.Context, regionUri string, dwServiceU
 	return &resp, nil
 }
 
+func (p *RelytClient) PatchDpsDescription(ctx context.Context, regionUri string, dwServiceUnitId, dpsId string, description string) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s/dps/%s", dwServiceUnitId, dpsId)
+	resp := CommonRelytResponse[string]{}
+	if err := doHttpRequest(p, ctx, regionUri, path, "PATCH", &resp, DpsPatch{Description: &description}, nil, nil); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (p *RelytClient) SuspendDps(ctx context.Context, regionUri, dwServiceUnitId, dpsId string) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s/dps/%s/suspend", dwServiceUnitId, dpsId)
+	resp := CommonRelytResponse[string]{}
+	if err := doHttpRequest(p, ctx, regionUri, path, "POST", &resp, nil, nil, nil); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (p *RelytClient) ResumeDps(ctx context.Context, regionUri, dwServiceUnitId, dpsId string) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s/dps/%s/resume", dwServiceUnitId, dpsId)
+	resp := CommonRelytResponse[string]{}
+	if err := doHttpRequest(p, ctx, regionUri, path, "POST", &resp, nil, nil, nil); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (p *RelytClient) GetDps(ctx context.Context, regionUri, dwServiceUnitId, dpsBizId string) (*DpsMode, error) {
 	path := fmt.Sprintf("/dwsu/%s/dps/%s", dwServiceUnitId, dpsBizId)
 	resp := CommonRelytResponse[DpsMode]{}
@@ -134,11 +183,10 @@ func (p *RelytClient) GetDps(ctx context.Context, regionUri, dwServiceUnitId, dp
 func (p *RelytClient) DropDps(ctx context.Context, regionUri, dwServiceUnitId, dpsBizId string) error {
 	path := fmt.Sprintf("/dwsu/%s/dps/%s", dwServiceUnitId, dpsBizId)
 	resp := CommonRelytResponse[string]{}
-	handler := func(response *CommonRelytResponse[string], respString []byte) (*CommonRelytResponse[string], error) {
-		if response.Code != CODE_SUCCESS && resp.Code != CODE_DPS_NOT_FOUND {
-			body := string(respString)
-			tflog.Error(ctx, "error call api! resp code not success! body: "+body)
-			return response, fmt.Errorf(body)
+	handler := func(response *CommonRelytResponse[string], apiErr *APIError) (*CommonRelytResponse[string], error) {
+		if apiErr != nil && !IsNotFound(apiErr) {
+			tflog.Error(ctx, "error call api! resp code not success! "+apiErr.Error())
+			return response, apiErr
 		}
 		return nil, nil
 	}
@@ -158,6 +206,9 @@ func (p *RelytClient) ListSpec(ctx context.Context, edition, dpsType, cloud, reg
 	if err != nil {
 		return nil, err
 	}
+	if specList.Data == nil {
+		return nil, nil
+	}
 	return *specList.Data, nil
 }
 
@@ -192,14 +243,34 @@ func (p *RelytClient) GetAccount(ctx context.Context, regionUri string, dwsuId s
 	return &resp, nil
 }
 
+// ListAccounts lists a page of the accounts of a dwsu, only the ones whose name starts with namePrefix if it's not empty.
+func (p *RelytClient) ListAccounts(ctx context.Context, regionUri, dwsuId string, pageSize, pageNumber int, namePrefix string) ([]*AccountInfo, error) {
+	resp := CommonRelytResponse[CommonPage[AccountInfo]]{}
+	pageQuery := map[string]string{
+		"pageSize":   strconv.Itoa(pageSize),
+		"pageNumber": strconv.Itoa(pageNumber),
+	}
+	if namePrefix != "" {
+		pageQuery["namePrefix"] = namePrefix
+	}
+	path := fmt.Sprintf("/dwsu/%s/user", dwsuId)
+	err := doHttpRequest(p, ctx, regionUri, path, "GET", &resp, nil, pageQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.Records, nil
+}
+
 func (p *RelytClient) DropAccount(ctx context.Context, regionUri string, dwsuId string, userId string) error {
 	path := fmt.Sprintf("/dwsu/%s/user/%s", dwsuId, url.PathEscape(userId))
 	resp := CommonRelytResponse[string]{}
-	handler := func(response *CommonRelytResponse[string], respString []byte) (*CommonRelytResponse[string], error) {
-		if response.Code != CODE_SUCCESS && resp.Code != CODE_USER_NOT_FOUND {
-			body := string(respString)
-			tflog.Error(ctx, "error call api! resp code not success! body: "+body)
-			return response, fmt.Errorf(body)
+	handler := func(response *CommonRelytResponse[string], apiErr *APIError) (*CommonRelytResponse[string], error) {
+		if apiErr != nil && !IsNotFound(apiErr) {
+			tflog.Error(ctx, "error call api! resp code not success! "+apiErr.Error())
+			return response, apiErr
 		}
 		return nil, nil
 	}
@@ -207,6 +278,86 @@ func (p *RelytClient) DropAccount(ctx context.Context, regionUri string, dwsuId
 	return err
 }
 
+func (p *RelytClient) CreateRole(ctx context.Context, regionUri, dwsuId string, role Role) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s/role", dwsuId)
+	resp := CommonRelytResponse[string]{}
+	err := doHttpRequest(p, ctx, regionUri, path, "POST", &resp, role, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (p *RelytClient) GetRole(ctx context.Context, regionUri, dwsuId, roleName string) (*Role, error) {
+	path := fmt.Sprintf("/dwsu/%s/role/%s", dwsuId, url.PathEscape(roleName))
+	resp := CommonRelytResponse[Role]{}
+	err := doHttpRequest(p, ctx, regionUri, path, "GET", &resp, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (p *RelytClient) PatchRole(ctx context.Context, regionUri, dwsuId, roleName, description string) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s/role/%s", dwsuId, url.PathEscape(roleName))
+	resp := CommonRelytResponse[string]{}
+	err := doHttpRequest(p, ctx, regionUri, path, "PATCH", &resp, map[string]any{"description": description}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (p *RelytClient) DropRole(ctx context.Context, regionUri, dwsuId, roleName string) error {
+	path := fmt.Sprintf("/dwsu/%s/role/%s", dwsuId, url.PathEscape(roleName))
+	resp := CommonRelytResponse[string]{}
+	handler := func(response *CommonRelytResponse[string], apiErr *APIError) (*CommonRelytResponse[string], error) {
+		if apiErr != nil && !IsNotFound(apiErr) {
+			tflog.Error(ctx, "error call api! resp code not success! "+apiErr.Error())
+			return response, apiErr
+		}
+		return nil, nil
+	}
+	return doHttpRequest(p, ctx, regionUri, path, "DELETE", &resp, nil, nil, handler)
+}
+
+// ListUserRoles lists the roles granted to a dw user.
+func (p *RelytClient) ListUserRoles(ctx context.Context, regionUri, dwsuId, userId string) ([]*Role, error) {
+	path := fmt.Sprintf("/dwsu/%s/user/%s/role", dwsuId, url.PathEscape(userId))
+	resp := CommonRelytResponse[[]*Role]{}
+	err := doHttpRequest(p, ctx, regionUri, path, "GET", &resp, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return *resp.Data, nil
+}
+
+func (p *RelytClient) GrantRole(ctx context.Context, regionUri, dwsuId, userId, roleName string) (*CommonRelytResponse[string], error) {
+	path := fmt.Sprintf("/dwsu/%s/user/%s/role", dwsuId, url.PathEscape(userId))
+	resp := CommonRelytResponse[string]{}
+	err := doHttpRequest(p, ctx, regionUri, path, "POST", &resp, map[string]any{"roleName": roleName}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (p *RelytClient) RevokeRole(ctx context.Context, regionUri, dwsuId, userId, roleName string) error {
+	path := fmt.Sprintf("/dwsu/%s/user/%s/role/%s", dwsuId, url.PathEscape(userId), url.PathEscape(roleName))
+	resp := CommonRelytResponse[string]{}
+	handler := func(response *CommonRelytResponse[string], apiErr *APIError) (*CommonRelytResponse[string], error) {
+		if apiErr != nil && !IsNotFound(apiErr) {
+			tflog.Error(ctx, "error call api! resp code not success! "+apiErr.Error())
+			return response, apiErr
+		}
+		return nil, nil
+	}
+	return doHttpRequest(p, ctx, regionUri, path, "DELETE", &resp, nil, nil, handler)
+}
+
 func (p *RelytClient) AsyncAccountConfig(ctx context.Context, regionUri, dwsuId, userId string, asyncResult AsyncResult) (*CommonRelytResponse[string], error) {
 	path := fmt.Sprintf("/dwsu/%s/user/%s/asyncresult", dwsuId, url.PathEscape(userId))
 	resp := CommonRelytResponse[string]{}
@@ -297,7 +448,7 @@ func (p *RelytClient) GetDwsuOpenApiMeta(ctx context.Context, dwsuId string) (*O
 		return nil, err
 	}
 	if dwsu == nil {
-		return nil, fmt.Errorf("can't find dwsu meta! %s", dwsuId)
+		return nil, &APIError{Code: CODE_DWSU_NOT_FOUND, Msg: "can't find dwsu meta! " + dwsuId, Method: "GET", Endpoint: "/dwsu/" + dwsuId}
 	}
 	meta, err := p.GetOpenApiMeta(ctx, dwsu.Region.Cloud.ID, dwsu.Region.ID)
 	return meta, err
@@ -320,7 +471,8 @@ func (p *RelytClient) CreatePrivateLinkService(ctx context.Context, regionUri, d
 	pl.ServiceName = ""
 	pl.Status = ""
 	header := map[string]string{"x-maxone-idempotent": "false"}
-	err := doHttpRequestWithHeader(p, ctx, regionUri, path, "PUT", &resp, pl, nil, header, nil)
+	// 创建private link的PUT不是幂等的，不重试
+	err := doHttpRequestWithHeader(p, ctx, regionUri, path, "PUT", &resp, pl, nil, header, false, nil)
 	if err != nil {
 		tflog.Error(ctx, "Error create private-link:"+err.Error())
 		return nil, err
@@ -1,15 +1,25 @@
 package client
 
 const (
-	DPS_STATUS_READY     = "READY"
-	DPS_STATUS_DROPPED   = "DROPPED"
-	PRIVATE_LINK_READY   = "READY"
-	PRIVATE_LINK_UNKNOWN = "UNKNOWN"
-	CODE_SUCCESS         = 200
-	CODE_USER_NOT_FOUND  = 134084
-	//CODE_ROLE_NOT_EXIST = 134085
-	CODE_DPS_NOT_FOUND  = 137073
-	CODE_DWSU_NOT_FOUND = 65544
+	DPS_STATUS_READY          = "READY"
+	DPS_STATUS_DROPPED        = "DROPPED"
+	DPS_STATUS_SUSPENDED      = "SUSPENDED"
+	DPS_ENGINE_HYBRID         = "hybrid"
+	DPS_ENGINE_EXTREME        = "extreme"
+	DPS_ENGINE_VECTOR         = "vector"
+	PRIVATE_LINK_READY        = "READY"
+	PRIVATE_LINK_UNKNOWN      = "UNKNOWN"
+	ENDPOINT_TYPE_DATABASE    = "database"
+	ENDPOINT_TYPE_OPENAPI     = "openapi"
+	ENDPOINT_TYPE_WEB_CONSOLE = "web_console"
+	OBJECT_TYPE_DATABASE      = "database"
+	OBJECT_TYPE_SCHEMA        = "schema"
+	OBJECT_TYPE_TABLE         = "table"
+	CODE_SUCCESS              = 200
+	CODE_USER_NOT_FOUND       = 134084
+	CODE_ROLE_NOT_EXIST       = 134085
+	CODE_DPS_NOT_FOUND        = 137073
+	CODE_DWSU_NOT_FOUND       = 65544
 )
 
 type CommonRelytResponse[T any] struct {
@@ -64,12 +74,12 @@ type DpsMode struct {
 	CreateTime                 int64    `json:"createTime,omitempty"`
 	Creator                    *Creator `json:"creator,omitempty"`
 	Description                string   `json:"description,omitempty"`
-	EnableAdaptiveQueryScaling bool     `json:"enableAdaptiveQueryScaling,omitempty"`
-	EnableAutoResume           bool     `json:"enableAutoResume,omitempty"`
-	EnableAutoSuspend          bool     `json:"enableAutoSuspend,omitempty"`
+	EnableAdaptiveQueryScaling *bool    `json:"enableAdaptiveQueryScaling,omitempty"`
+	EnableAutoResume           *bool    `json:"enableAutoResume,omitempty"`
+	EnableAutoSuspend          *bool    `json:"enableAutoSuspend,omitempty"`
 	Engine                     string   `json:"engine,omitempty"`
 	ID                         string   `json:"id,omitempty"`
-	KeepAliveTime              int      `json:"keepAliveTime,omitempty"`
+	KeepAliveTime              *int64   `json:"keepAliveTime,omitempty"`
 	Name                       string   `json:"name,omitempty"`
 	Owner                      *Owner   `json:"owner,omitempty"`
 	Spec                       *Spec    `json:"spec,omitempty"`
@@ -141,6 +151,17 @@ type DwsuModel struct {
 	Variant         *Variant    `json:"variant,omitempty"`
 }
 
+// DpsPatch carries the description of a dps, unlike DpsMode an empty description is sent to clear it.
+type DpsPatch struct {
+	Description *string `json:"description,omitempty"`
+}
+
+// DwsuPatch only carries the fields of a dwsu that can be changed in place, a nil field is left unchanged.
+type DwsuPatch struct {
+	Alias *string   `json:"alias,omitempty"`
+	Tags  *[]string `json:"tags,omitempty"`
+}
+
 type LakeFormation struct {
 	IAMRole string `json:"iamRole,omitempty"`
 }
@@ -155,6 +176,16 @@ type Account struct {
 	Name         string `json:"name,omitempty"`
 }
 
+// AccountInfo is an account in the account list of a dwsu.
+type AccountInfo struct {
+	ID              string         `json:"id,omitempty"`
+	Name            string         `json:"name,omitempty"`
+	CreateTimestamp int64          `json:"createTimestamp,omitempty"`
+	MfaEnabled      bool           `json:"mfaEnabled,omitempty"`
+	AsyncResult     *AsyncResult   `json:"asyncResult,omitempty"`
+	LakeFormation   *LakeFormation `json:"lakeFormation,omitempty"`
+}
+
 type OpenApiMetaInfo struct {
 	ID       string `json:"id,omitempty"`
 	Type     string `json:"type,omitempty"`
@@ -246,6 +277,23 @@ type SchemaMeta struct {
 //	Views  int `json:"views"`
 //}
 
+type Role struct {
+	Name            string   `json:"name,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	Creator         *Creator `json:"creator,omitempty"`
+	CreateTimestamp int64    `json:"createTimestamp,omitempty"`
+}
+
+// Privilege is the privileges of a grantee on a database, schema or table.
+type Privilege struct {
+	Grantee    *string  `json:"grantee,omitempty"`
+	ObjectType *string  `json:"objectType,omitempty"`
+	Database   *string  `json:"database,omitempty"`
+	Schema     *string  `json:"schema,omitempty"`
+	Table      *string  `json:"table,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+}
+
 type SchemaPageQuery struct {
 	PageQuery
 	Database *string `json:"database,omitempty"`
@@ -2,14 +2,20 @@ package client
 
 import (
 	"context"
+	"net/http"
 )
 
 func NewRelytDatabaseClient(config RelytDatabaseClientConfig) (RelytDatabaseClient, error) {
-	return RelytDatabaseClient{config}, nil
+	var transport http.RoundTripper = config.transport
+	if config.transport == nil {
+		transport = newHttpTransport()
+	}
+	return RelytDatabaseClient{config, newHttpClient(transport, config.ClientTimeout)}, nil
 }
 
 type RelytDatabaseClient struct {
 	RelytDatabaseClientConfig
+	httpClient *http.Client
 }
 
 type RelytDatabaseClientConfig struct {
@@ -17,13 +23,17 @@ type RelytDatabaseClientConfig struct {
 	AccessKey     string `json:"accessKey"`
 	SecretKey     string `json:"secretKey"`
 	ClientTimeout int32  `json:"clientTimeout"`
+	LogRedactConfig
+	RetryConfig
+	// transport is shared with RelytClient when the config comes from NewRelytClient.
+	transport *http.Transport
 }
 
 func (r *RelytDatabaseClient) CreateDatabase(ctx context.Context, database Database) (*Database, error) {
 	resp := CommonRelytResponse[Database]{}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/database/create",
-		"POST", &resp, database, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, database, nil, nil, r,
+		false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,8 +44,8 @@ func (r *RelytDatabaseClient) DropDatabase(ctx context.Context, name string) (bo
 	resp := CommonRelytResponse[bool]{}
 
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/database/drop",
-		"POST", &resp, &Database{Name: &name}, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, &Database{Name: &name}, nil, nil, r,
+		false, nil)
 	if err != nil {
 		return false, err
 	}
@@ -46,8 +56,8 @@ func (r *RelytDatabaseClient) ListDatabase(ctx context.Context, pageSize, pageNu
 	resp := CommonRelytResponse[CommonPage[Database]]{}
 	pageQuery := PageQuery{PageSize: pageSize, PageNumber: pageNumber}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/database/list",
-		"POST", &resp, &pageQuery, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, &pageQuery, nil, nil, r,
+		true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -57,8 +67,8 @@ func (r *RelytDatabaseClient) ListDatabase(ctx context.Context, pageSize, pageNu
 func (r *RelytDatabaseClient) GetDatabase(ctx context.Context, name string) (*Database, error) {
 	resp := CommonRelytResponse[Database]{}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/database/detail",
-		"POST", &resp, Database{Name: &name}, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, Database{Name: &name}, nil, nil, r,
+		true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +78,7 @@ func (r *RelytDatabaseClient) GetDatabase(ctx context.Context, name string) (*Da
 //func (r *RelytDatabaseClient) createSchema(ctx context.Context, schema SchemaMeta) (*SchemaMeta, error) {
 //	resp := CommonRelytResponse[SchemaMeta]{}
 //	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/schema/create",
-//		"POST", &resp, &schema, nil, nil, &r.RelytDatabaseClientConfig,
+//		"POST", &resp, &schema, nil, nil, r,
 //		nil)
 //	if err != nil {
 //		return nil, err
@@ -79,8 +89,8 @@ func (r *RelytDatabaseClient) GetDatabase(ctx context.Context, name string) (*Da
 func (r *RelytDatabaseClient) CreateExternalSchema(ctx context.Context, schema Schema) (*SchemaMeta, error) {
 	resp := CommonRelytResponse[SchemaMeta]{}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/external-schema/create",
-		"POST", &resp, &schema, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, &schema, nil, nil, r,
+		false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -90,8 +100,8 @@ func (r *RelytDatabaseClient) CreateExternalSchema(ctx context.Context, schema S
 func (r *RelytDatabaseClient) DropSchema(ctx context.Context, schema Schema) (bool, error) {
 	resp := CommonRelytResponse[bool]{}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/schema/drop",
-		"POST", &resp, Schema{Database: schema.Database, Catalog: schema.Catalog, Name: schema.Name}, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, Schema{Database: schema.Database, Catalog: schema.Catalog, Name: schema.Name}, nil, nil, r,
+		false, nil)
 	if err != nil {
 		return false, err
 	}
@@ -101,8 +111,8 @@ func (r *RelytDatabaseClient) DropSchema(ctx context.Context, schema Schema) (bo
 func (r *RelytDatabaseClient) ListSchemas(ctx context.Context, query SchemaPageQuery) (*CommonPage[SchemaMeta], error) {
 	resp := CommonRelytResponse[CommonPage[SchemaMeta]]{}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/schema/list",
-		"POST", &resp, query, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, query, nil, nil, r,
+		true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +122,43 @@ func (r *RelytDatabaseClient) ListSchemas(ctx context.Context, query SchemaPageQ
 func (r *RelytDatabaseClient) GetExternalSchema(ctx context.Context, schema Schema) (*SchemaMeta, error) {
 	resp := CommonRelytResponse[SchemaMeta]{}
 	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/external-schema/detail",
-		"POST", &resp, Schema{Database: schema.Database, Catalog: schema.Catalog, Name: schema.Name}, nil, nil, &r.RelytDatabaseClientConfig,
-		nil)
+		"POST", &resp, Schema{Database: schema.Database, Catalog: schema.Catalog, Name: schema.Name}, nil, nil, r,
+		true, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (r *RelytDatabaseClient) GrantPrivileges(ctx context.Context, privilege Privilege) (bool, error) {
+	resp := CommonRelytResponse[bool]{}
+	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/privilege/grant",
+		"POST", &resp, privilege, nil, nil, r,
+		false, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Data != nil && *resp.Data, nil
+}
+
+func (r *RelytDatabaseClient) RevokePrivileges(ctx context.Context, privilege Privilege) (bool, error) {
+	resp := CommonRelytResponse[bool]{}
+	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/privilege/revoke",
+		"POST", &resp, privilege, nil, nil, r,
+		false, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Data != nil && *resp.Data, nil
+}
+
+// GetPrivileges returns the privileges the grantee holds on the object of privilege.
+func (r *RelytDatabaseClient) GetPrivileges(ctx context.Context, privilege Privilege) (*Privilege, error) {
+	resp := CommonRelytResponse[Privilege]{}
+	err := signedHttpRequestWithHeader(nil, ctx, r.DmsHost, "/api/catalog/privilege/detail",
+		"POST", &resp, Privilege{Grantee: privilege.Grantee, ObjectType: privilege.ObjectType,
+			Database: privilege.Database, Schema: privilege.Schema, Table: privilege.Table}, nil, nil, r,
+		true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned for every failed call to the Relyt api, either a non 200 http status or a
+// response body whose code isn't CODE_SUCCESS.
+type APIError struct {
+	HttpStatus int
+	Code       int
+	Msg        string
+	RequestId  string
+	Method     string
+	Endpoint   string
+	// Body is the redacted response body, kept for responses without a msg.
+	Body string
+}
+
+// maxErrorBodySize caps how much of the response body ends up in an error message.
+const maxErrorBodySize = 512
+
+func (e *APIError) Error() string {
+	msg := e.Msg
+	if msg == "" {
+		msg = e.Body
+		if len(msg) > maxErrorBodySize {
+			msg = msg[:maxErrorBodySize] + "...(truncated)"
+		}
+	}
+	return fmt.Sprintf("relyt api %s %s failed! http status: %d code: %d request id: %s msg: %s",
+		e.Method, e.Endpoint, e.HttpStatus, e.Code, e.RequestId, msg)
+}
+
+var notFoundCodes = map[int]bool{
+	CODE_DWSU_NOT_FOUND: true,
+	CODE_DPS_NOT_FOUND:  true,
+	CODE_USER_NOT_FOUND: true,
+	CODE_ROLE_NOT_EXIST: true,
+}
+
+// AsAPIError unwraps err into an *APIError, returning nil if err isn't one.
+func AsAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return nil
+}
+
+// IsNotFound reports whether the target of the call doesn't exist (any more).
+func IsNotFound(err error) bool {
+	apiErr := AsAPIError(err)
+	return apiErr != nil && (apiErr.HttpStatus == http.StatusNotFound || notFoundCodes[apiErr.Code])
+}
+
+// IsConflict reports whether the call was rejected because of the current state of the target.
+func IsConflict(err error) bool {
+	apiErr := AsAPIError(err)
+	return apiErr != nil && apiErr.HttpStatus == http.StatusConflict
+}
+
+// IsThrottled reports whether the call was rejected by rate limiting.
+func IsThrottled(err error) bool {
+	apiErr := AsAPIError(err)
+	return apiErr != nil && apiErr.HttpStatus == http.StatusTooManyRequests
+}
+
+// IsUnauthorized reports whether the auth key, role or access key was rejected.
+func IsUnauthorized(err error) bool {
+	apiErr := AsAPIError(err)
+	return apiErr != nil && (apiErr.HttpStatus == http.StatusUnauthorized || apiErr.HttpStatus == http.StatusForbidden)
+}
+
+// IsValidation reports whether the request itself was rejected as invalid.
+func IsValidation(err error) bool {
+	apiErr := AsAPIError(err)
+	return apiErr != nil && apiErr.HttpStatus == http.StatusBadRequest
+}
+
+// IsRetryable reports whether the same call may succeed later: throttling or a server side failure.
+func IsRetryable(err error) bool {
+	apiErr := AsAPIError(err)
+	return apiErr != nil && (apiErr.HttpStatus == http.StatusTooManyRequests || apiErr.HttpStatus >= http.StatusInternalServerError)
+}
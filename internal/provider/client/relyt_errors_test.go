@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_classify(t *testing.T) {
+	notFound := &APIError{HttpStatus: http.StatusOK, Code: CODE_DPS_NOT_FOUND}
+	wrapped := fmt.Errorf("read dps: %w", notFound)
+	if !IsNotFound(notFound) || !IsNotFound(wrapped) {
+		t.Errorf("dps not found code should be classified as not found")
+	}
+	if IsRetryable(notFound) {
+		t.Errorf("not found shouldn't be retryable")
+	}
+	if !IsRetryable(&APIError{HttpStatus: http.StatusServiceUnavailable}) || !IsRetryable(&APIError{HttpStatus: http.StatusTooManyRequests}) {
+		t.Errorf("5xx and 429 should be retryable")
+	}
+	if IsRetryable(&APIError{HttpStatus: http.StatusBadRequest}) || !IsValidation(&APIError{HttpStatus: http.StatusBadRequest}) {
+		t.Errorf("400 should be a validation error and not retryable")
+	}
+	if !IsConflict(&APIError{HttpStatus: http.StatusConflict}) {
+		t.Errorf("409 should be a conflict")
+	}
+	if IsNotFound(fmt.Errorf("plain error")) || AsAPIError(nil) != nil {
+		t.Errorf("plain errors aren't api errors")
+	}
+}
+
+func TestCodeHandler_httpNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"msg":"not found"}`))
+	}))
+	defer server.Close()
+	p, _ := NewRelytClient(RelytClientConfig{ApiHost: server.URL, ClientTimeout: 5})
+
+	if err := p.DropAccount(context.Background(), server.URL, "dwsu", "user"); err != nil {
+		t.Errorf("drop a missing account should succeed, got %v", err)
+	}
+	dwsu, err := p.GetDwsu(context.Background(), "dwsu")
+	if err != nil || dwsu != nil {
+		t.Errorf("get a missing dwsu should return nil, got %v %v", dwsu, err)
+	}
+	if _, err := p.GetAccount(context.Background(), server.URL, "dwsu", "user"); !IsNotFound(err) {
+		t.Errorf("calls without code handler should still return not found, got %v", err)
+	}
+}
+
+func TestAPIError_redactBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"data":{"password":"hunter2","comment":"` + strings.Repeat("a", 2*maxErrorBodySize) + `"}}`))
+	}))
+	defer server.Close()
+	p, _ := NewRelytClient(RelytClientConfig{ApiHost: server.URL, ClientTimeout: 5})
+
+	_, err := p.GetAccount(context.Background(), server.URL, "dwsu", "user")
+	if err == nil {
+		t.Fatalf("expect an error for http 400")
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("error message leaks the password: %s", err.Error())
+	}
+	if !strings.Contains(err.Error(), "truncated") || len(err.Error()) > maxErrorBodySize+256 {
+		t.Errorf("error message should be truncated, got %d bytes", len(err.Error()))
+	}
+}
@@ -6,15 +6,14 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"io/ioutil"
+	"io"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -22,18 +21,21 @@ func doHttpRequest[T any](p *RelytClient, ctx context.Context, host, path, metho
 	respMode *CommonRelytResponse[T],
 	request any,
 	parameter map[string]string,
-	codeHandler func(response *CommonRelytResponse[T], respDumpByte []byte) (*CommonRelytResponse[T], error)) (err error) {
-	return doHttpRequestWithHeader(p, ctx, host, path, method, respMode, request, parameter, nil, codeHandler)
+	codeHandler func(response *CommonRelytResponse[T], apiErr *APIError) (*CommonRelytResponse[T], error)) (err error) {
+	return doHttpRequestWithHeader(p, ctx, host, path, method, respMode, request, parameter, nil, isIdempotent(method), codeHandler)
 }
 
+// doHttpRequestWithHeader is doHttpRequest with extra headers, retryable tells whether the call may be retried
+// regardless of its method, e.g. false for a PUT that isn't idempotent.
 func doHttpRequestWithHeader[T any](p *RelytClient, ctx context.Context, host, path, method string,
 	respMode *CommonRelytResponse[T],
 	request any,
 	parameter map[string]string,
 	header map[string]string,
-	codeHandler func(response *CommonRelytResponse[T], respDumpByte []byte) (*CommonRelytResponse[T], error)) (err error) {
+	retryable bool,
+	codeHandler func(response *CommonRelytResponse[T], apiErr *APIError) (*CommonRelytResponse[T], error)) (err error) {
 	return signedHttpRequestWithHeader(p, ctx, host, path, method,
-		respMode, request, parameter, header, nil, codeHandler)
+		respMode, request, parameter, header, nil, retryable, codeHandler)
 	//if host == "" {
 	//	host = p.ApiHost
 	//}
@@ -128,11 +130,31 @@ func signedHttpRequestWithHeader[T any](p *RelytClient, ctx context.Context, hos
 	request any,
 	parameter map[string]string,
 	header map[string]string,
-	databaseClientConfig *RelytDatabaseClientConfig,
-	codeHandler func(response *CommonRelytResponse[T], respDumpByte []byte) (*CommonRelytResponse[T], error)) (err error) {
+	databaseClient *RelytDatabaseClient,
+	retryable bool,
+	codeHandler func(response *CommonRelytResponse[T], apiErr *APIError) (*CommonRelytResponse[T], error)) (err error) {
 	if host == "" {
 		host = p.ApiHost
 	}
+	redactConfig := LogRedactConfig{}
+	retryConfig := RetryConfig{}
+	var httpClient *http.Client
+	var databaseClientConfig *RelytDatabaseClientConfig
+	if p != nil {
+		redactConfig = p.LogRedactConfig
+		retryConfig = p.RetryConfig
+		httpClient = p.httpClient
+	} else if databaseClient != nil {
+		databaseClientConfig = &databaseClient.RelytDatabaseClientConfig
+		redactConfig = databaseClientConfig.LogRedactConfig
+		retryConfig = databaseClientConfig.RetryConfig
+		httpClient = databaseClient.httpClient
+	}
+	if httpClient == nil {
+		//未通过New方法创建的client，退化为独立的http client
+		httpClient = newHttpClient(nil, 0)
+	}
+	redactor := newLogRedactor(redactConfig)
 	jsonBody := false
 	var jsonData = []byte("")
 	if request != nil && "" != request {
@@ -141,7 +163,6 @@ func signedHttpRequestWithHeader[T any](p *RelytClient, ctx context.Context, hos
 		if err != nil {
 			tflog.Error(ctx, "fmt request json error:"+err.Error())
 		}
-		tflog.Info(ctx, "request data :"+string(requestJson))
 		jsonData = requestJson // POST请求发送的数据
 	}
 	hostApi := host + path
@@ -159,36 +180,32 @@ func signedHttpRequestWithHeader[T any](p *RelytClient, ctx context.Context, hos
 	//parsedHostApi.Opaque = host
 	//parsedHostApi.RawQuery
 
-	req, err := http.NewRequest(method, parsedHostApi.String(), bytes.NewBuffer(jsonData))
-	if err != nil {
-		tflog.Error(ctx, "Error creating request:"+err.Error())
-		return err
-	}
-	if p != nil {
-		req.Header.Set("x-maxone-api-key", p.AuthKey)
-		req.Header.Set("x-maxone-role-id", p.Role)
-	}
-	if jsonBody {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if header != nil {
-		for k, v := range header {
-			req.Header.Set(k, v)
-		}
-	}
-	clientTimeout := 10 * time.Second
-	if databaseClientConfig != nil {
-		err = AwsSignHttp(databaseClientConfig, req, jsonData)
+	//每次重试都要重新构造body并重新签名
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, parsedHostApi.String(), bytes.NewBuffer(jsonData))
 		if err != nil {
-			tflog.Error(ctx, "error sign request"+err.Error())
-			return err
+			return nil, err
 		}
-		if databaseClientConfig.ClientTimeout > 0 {
-			clientTimeout = time.Duration(databaseClientConfig.ClientTimeout) * time.Second
+		if p != nil {
+			req.Header.Set("x-maxone-api-key", p.AuthKey)
+			req.Header.Set("x-maxone-role-id", p.Role)
 		}
-	}
-	if p != nil {
-		clientTimeout = time.Duration(p.ClientTimeout) * time.Second
+		if jsonBody {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if header != nil {
+			for k, v := range header {
+				req.Header.Set(k, v)
+			}
+		}
+		if databaseClientConfig != nil {
+			err = AwsSignHttp(ctx, databaseClientConfig, req, jsonData)
+			if err != nil {
+				tflog.Error(ctx, "error sign request"+err.Error())
+				return nil, err
+			}
+		}
+		return req, nil
 	}
 
 	requestId := ""
@@ -196,39 +213,81 @@ func signedHttpRequestWithHeader[T any](p *RelytClient, ctx context.Context, hos
 	if uuidErr == nil {
 		requestId = requestUUID.String()
 	}
-	requestString, _ := httputil.DumpRequestOut(req, true)
-	tflog.Info(ctx, "== apiId : "+requestId+" request: "+string(requestString))
-	client := &http.Client{Timeout: clientTimeout}
-	resp, err := client.Do(req)
-	if err != nil {
-		tflog.Error(ctx, "Error sending request:"+err.Error())
-		return err
+	var resp *http.Response
+	var body []byte
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			tflog.Error(ctx, "Error creating request:"+err.Error())
+			return err
+		}
+		tflog.Debug(ctx, "== apiId : "+requestId+" request: "+method+" "+parsedHostApi.String()+" attempt: "+strconv.Itoa(attempt))
+		tflog.Trace(ctx, "== apiId : "+requestId+" request header:\n"+redactor.header(req.Header)+"request body: "+redactor.body(jsonData))
+		resp, err = httpClient.Do(req)
+		if err == nil {
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		if !retryable || attempt >= retryConfig.MaxRetries || !shouldRetry(ctx, resp, err) {
+			if err != nil {
+				tflog.Error(ctx, "Error sending request:"+err.Error())
+				return err
+			}
+			break
+		}
+		wait := retryConfig.backoff(attempt, resp)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+		}
+		tflog.Warn(ctx, "== apiId : "+requestId+" retry after "+wait.String()+" attempt: "+strconv.Itoa(attempt)+" reason: "+reason)
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return err
+		}
 	}
-	defer resp.Body.Close()
-	responseString, _ := httputil.DumpResponse(resp, true)
-	tflog.Info(ctx, "== apiId : "+requestId+" response: "+string(responseString))
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		tflog.Error(ctx, "Error reading responseString body:"+err.Error())
-		return err
+	tflog.Debug(ctx, "== apiId : "+requestId+" response: "+resp.Status)
+	tflog.Trace(ctx, "== apiId : "+requestId+" response header:\n"+redactor.header(resp.Header)+"response body: "+redactor.body(body))
+	respRequestId := resp.Header.Get("x-request-id")
+	if respRequestId == "" {
+		respRequestId = requestId
 	}
+	newApiError := func() *APIError {
+		return &APIError{
+			HttpStatus: resp.StatusCode,
+			Code:       respMode.Code,
+			Msg:        respMode.Msg,
+			RequestId:  respRequestId,
+			Method:     method,
+			Endpoint:   path,
+			//错误信息会展示给用户，不能带出敏感字段
+			Body: redactor.body(body),
+		}
+	}
+	var apiErr *APIError
 	if resp.StatusCode != CODE_SUCCESS {
 		tflog.Error(ctx, "Error status http code not 200! "+resp.Status)
 		//printResp(ctx, resp)
-		return fmt.Errorf("Error http code not 200! respCode: %s!\n%s ", resp.Status, string(body))
-	}
-
-	err = json.Unmarshal(body, respMode)
-	if err != nil {
-		tflog.Error(ctx, "read json respFail:"+err.Error())
-		return err
-	}
-	if respMode.Code != CODE_SUCCESS {
-		tflog.Warn(ctx, "error call api! resp code not 200: "+string(body))
+		//错误响应体不一定是json，解析失败时只保留http status
+		_ = json.Unmarshal(body, respMode)
+		//错误响应不带data，交给codeHandler判断是否可以容忍（如删除时的404）
+		respMode.Data = nil
+		apiErr = newApiError()
+	} else {
+		err = json.Unmarshal(body, respMode)
+		if err != nil {
+			tflog.Error(ctx, "read json respFail:"+err.Error())
+			return err
+		}
+		if respMode.Code != CODE_SUCCESS {
+			tflog.Warn(ctx, "error call api! resp code not 200: "+redactor.body(body))
+			apiErr = newApiError()
+		}
 	}
 	if codeHandler != nil {
 		tflog.Trace(ctx, "use code handle func!")
-		handler, err := codeHandler(respMode, body)
+		handler, err := codeHandler(respMode, apiErr)
 		if handler != nil {
 			respMode.Code = handler.Code
 			respMode.Data = handler.Data
@@ -238,15 +297,15 @@ func signedHttpRequestWithHeader[T any](p *RelytClient, ctx context.Context, hos
 			return err
 		}
 	} else {
-		if respMode.Code != CODE_SUCCESS {
-			tflog.Error(ctx, "error call api! resp code not 200: "+string(body))
-			return fmt.Errorf(string(body))
+		if apiErr != nil {
+			tflog.Error(ctx, "error call api! resp code not 200: "+redactor.body(body))
+			return apiErr
 		}
 	}
 	return nil
 }
 
-func AwsSignHttp(aksk *RelytDatabaseClientConfig, req *http.Request, body []byte) error {
+func AwsSignHttp(ctx context.Context, aksk *RelytDatabaseClientConfig, req *http.Request, body []byte) error {
 	credentials := aws.Credentials{
 		AccessKeyID:     aksk.AccessKey,
 		SecretAccessKey: aksk.SecretKey,
@@ -259,7 +318,7 @@ func AwsSignHttp(aksk *RelytDatabaseClientConfig, req *http.Request, body []byte
 	signer := v4.NewSigner()
 	hash := sha256.Sum256(body)
 	payloadHash := hex.EncodeToString(hash[:])
-	err := signer.SignHTTP(context.TODO(), credentials, req, payloadHash, "relyt", "default", time.Now())
+	err := signer.SignHTTP(ctx, credentials, req, payloadHash, "relyt", "default", time.Now())
 	if err != nil {
 		return err
 	}
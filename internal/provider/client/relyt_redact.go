@@ -0,0 +1,116 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	redactedValue = "***REDACTED***"
+	// maxLogBodySize caps the number of body bytes written to TRACE logs.
+	maxLogBodySize = 4 * 1024
+)
+
+// DefaultRedactHeaders are request/response headers whose value never shows up in logs.
+var DefaultRedactHeaders = []string{
+	"x-maxone-api-key",
+	"x-maxone-role-id",
+	"Authorization",
+	"X-Amz-Security-Token",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// DefaultRedactFields are json body fields (see relyt_data.go) whose value never shows up in logs.
+var DefaultRedactFields = []string{
+	"initPassword",
+	"accessKey",
+	"secretKey",
+	"authKey",
+}
+
+type LogRedactConfig struct {
+	RedactHeaders []string `json:"redactHeaders"`
+	RedactFields  []string `json:"redactFields"`
+}
+
+type logRedactor struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+// newLogRedactor merges the configured names with the defaults. Names are matched case-insensitively.
+func newLogRedactor(config LogRedactConfig) *logRedactor {
+	r := &logRedactor{headers: map[string]bool{}, fields: map[string]bool{}}
+	for _, h := range append(append([]string{}, DefaultRedactHeaders...), config.RedactHeaders...) {
+		r.headers[strings.ToLower(h)] = true
+	}
+	for _, f := range append(append([]string{}, DefaultRedactFields...), config.RedactFields...) {
+		r.fields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+// header renders headers one per line with sensitive values masked.
+func (r *logRedactor) header(header http.Header) string {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		value := strings.Join(header[k], ", ")
+		if r.headers[strings.ToLower(k)] {
+			value = redactedValue
+		}
+		sb.WriteString(k + ": " + value + "\n")
+	}
+	return sb.String()
+}
+
+// body masks sensitive json fields at any depth and truncates the result to maxLogBodySize.
+// Bodies that are not json are only truncated.
+func (r *logRedactor) body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	out := body
+	var parsed any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&parsed); err == nil {
+		if masked, err := json.Marshal(r.mask(parsed)); err == nil {
+			out = masked
+		}
+	}
+	if len(out) > maxLogBodySize {
+		return string(out[:maxLogBodySize]) + "...(truncated, total " + strconv.Itoa(len(out)) + " bytes)"
+	}
+	return string(out)
+}
+
+func (r *logRedactor) mask(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, inner := range v {
+			if r.fields[strings.ToLower(k)] {
+				v[k] = redactedValue
+				continue
+			}
+			v[k] = r.mask(inner)
+		}
+		return v
+	case []any:
+		for i, inner := range v {
+			v[i] = r.mask(inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogRedactor_body(t *testing.T) {
+	redactor := newLogRedactor(LogRedactConfig{RedactFields: []string{"externalId"}})
+	body := redactor.body([]byte(`{"code":200,"data":[{"accessKeyId":"id-1","secretKey":"s3cr3t","externalId":"ext"}],"initPassword":"p@ss"}`))
+	for _, secret := range []string{"s3cr3t", "p@ss", "ext\""} {
+		if strings.Contains(body, secret) {
+			t.Errorf("secret %q leaked in %s", secret, body)
+		}
+	}
+	if !strings.Contains(body, "id-1") {
+		t.Errorf("non sensitive field should be kept: %s", body)
+	}
+	long := redactor.body([]byte(strings.Repeat("a", maxLogBodySize+10)))
+	if !strings.Contains(long, "truncated") || len(long) > maxLogBodySize+64 {
+		t.Errorf("body should be truncated, got length %d", len(long))
+	}
+}
+
+func TestLogRedactor_header(t *testing.T) {
+	redactor := newLogRedactor(LogRedactConfig{})
+	header := http.Header{}
+	header.Set("x-maxone-api-key", "key")
+	header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=ak")
+	header.Set("Content-Type", "application/json")
+	dump := redactor.header(header)
+	if strings.Contains(dump, "key\n") || strings.Contains(dump, "Credential") {
+		t.Errorf("sensitive header leaked: %s", dump)
+	}
+	if !strings.Contains(dump, "application/json") {
+		t.Errorf("content type should be kept: %s", dump)
+	}
+}
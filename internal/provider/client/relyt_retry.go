@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryBaseBackoff       = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+type RetryConfig struct {
+	MaxRetries int `json:"maxRetries"`
+	// RetryMaxBackoff is the upper bound in seconds of a single wait between two attempts.
+	RetryMaxBackoff int32 `json:"retryMaxBackoff"`
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// isIdempotent reports whether a call of method may be sent again without side effects. Calls that differ
+// from their method pass retryable to doHttpRequestWithHeader explicitly.
+func isIdempotent(method string) bool {
+	return idempotentMethods[method]
+}
+
+// shouldRetry reports whether an attempt failed with a network error, 429 or 5xx.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func (c RetryConfig) maxBackoff() time.Duration {
+	if c.RetryMaxBackoff > 0 {
+		return time.Duration(c.RetryMaxBackoff) * time.Second
+	}
+	return defaultRetryMaxBackoff
+}
+
+// backoff returns the wait before the next attempt: Retry-After if the server sent one, otherwise
+// exponential backoff with full jitter. Both are capped by RetryMaxBackoff.
+func (c RetryConfig) backoff(attempt int, resp *http.Response) time.Duration {
+	maxBackoff := c.maxBackoff()
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, maxBackoff)
+	}
+	ceiling := maxBackoff
+	if attempt < 30 {
+		ceiling = min(retryBaseBackoff<<attempt, maxBackoff)
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// retryAfter parses the Retry-After header, either delay seconds or an http date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// sleepWithContext waits d, returning early with the context error if ctx is done.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry_policy(t *testing.T) {
+	if !isIdempotent("GET") || !isIdempotent("DELETE") || isIdempotent("POST") {
+		t.Errorf("only idempotent methods should be retried by default")
+	}
+	ctx := context.Background()
+	if !shouldRetry(ctx, nil, errors.New("connection reset")) || shouldRetry(ctx, nil, context.Canceled) {
+		t.Errorf("network errors should be retried, cancellation shouldn't")
+	}
+	if !shouldRetry(ctx, &http.Response{StatusCode: http.StatusBadGateway}, nil) ||
+		shouldRetry(ctx, &http.Response{StatusCode: http.StatusNotFound}, nil) {
+		t.Errorf("only 429 and 5xx should be retried")
+	}
+}
+
+func TestRetry_backoff(t *testing.T) {
+	config := RetryConfig{MaxRetries: 3, RetryMaxBackoff: 2}
+	for attempt := 0; attempt < 10; attempt++ {
+		if wait := config.backoff(attempt, nil); wait < 0 || wait > 2*time.Second {
+			t.Errorf("attempt %d backoff %s out of range", attempt, wait)
+		}
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+	if wait := config.backoff(0, resp); wait != time.Second {
+		t.Errorf("Retry-After should be used, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if wait := config.backoff(0, resp); wait != 2*time.Second {
+		t.Errorf("Retry-After should be capped by max backoff, got %s", wait)
+	}
+}
+
+func TestRetry_request(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"msg":"ok","data":"done"}`))
+	}))
+	defer server.Close()
+	p, _ := NewRelytClient(RelytClientConfig{ApiHost: server.URL, ClientTimeout: 5, RetryConfig: RetryConfig{MaxRetries: 3}})
+
+	resp := CommonRelytResponse[string]{}
+	if err := doHttpRequest(&p, context.Background(), "", "/test", "GET", &resp, nil, nil, nil); err != nil {
+		t.Fatalf("get should succeed after retries: %s", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+	err := doHttpRequest(&p, context.Background(), "", "/test", "POST", &resp, nil, nil, nil)
+	if !IsRetryable(err) || calls.Load() != 1 {
+		t.Errorf("post shouldn't be retried, calls: %d err: %v", calls.Load(), err)
+	}
+
+	calls.Store(0)
+	if err := doHttpRequestWithHeader(&p, context.Background(), "", "/test", "POST", &resp, nil, nil, nil, true, nil); err != nil || calls.Load() != 3 {
+		t.Errorf("post marked retryable should be retried, calls: %d err: %v", calls.Load(), err)
+	}
+
+	calls.Store(0)
+	err = doHttpRequestWithHeader(&p, context.Background(), "", "/test", "PUT", &resp, nil, nil, nil, false, nil)
+	if !IsRetryable(err) || calls.Load() != 1 {
+		t.Errorf("put marked not retryable shouldn't be retried, calls: %d err: %v", calls.Load(), err)
+	}
+}
+
+func TestRetry_cancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	p, _ := NewRelytClient(RelytClientConfig{ApiHost: server.URL, ClientTimeout: 10, RetryConfig: RetryConfig{MaxRetries: 3}})
+
+	cancelCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	resp := CommonRelytResponse[string]{}
+	err := doHttpRequest(&p, cancelCtx, "", "/test", "GET", &resp, nil, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("cancelled request should stop immediately, took %s", time.Since(start))
+	}
+}
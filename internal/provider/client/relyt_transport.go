@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+	// a plan with hundreds of users/databases talks to one console host and one dms host, keep enough
+	// idle connections per host so parallel resources don't handshake again.
+	maxIdleConns        = 100
+	maxIdleConnsPerHost = 32
+	idleConnTimeout     = 90 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+// newHttpTransport creates the transport shared by RelytClient and RelytDatabaseClient.
+func newHttpTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// newHttpClient wraps the shared transport with a per client timeout, a timeout <= 0 means defaultClientTimeout.
+func newHttpClient(transport http.RoundTripper, timeoutSeconds int32) *http.Client {
+	timeout := defaultClientTimeout
+	if timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
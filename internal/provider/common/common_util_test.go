@@ -20,22 +20,20 @@ func TestScroll(t *testing.T) {
 	databaseClient, _ := client.NewRelytDatabaseClient(relytDatabaseClientConfig)
 	start := time.Now()
 	records, _ := ScrollPageRecords(&diag.Diagnostics{}, func(pageSize, pageNum int) ([]*client.SchemaMeta, error) {
-		listRecords, err := CommonRetry(context.TODO(), func() (*client.CommonPage[client.SchemaMeta], error) {
-			start = time.Now()
-			schemas, err := databaseClient.ListSchemas(context.TODO(), client.SchemaPageQuery{
-				PageQuery: client.PageQuery{
-					PageSize:   pageSize,
-					PageNumber: pageNum,
-				},
-				Database: "catalog",
-			})
-			msg := ""
-			if err != nil {
-				msg += err.Error()
-			}
-			println("http call cost:" + (time.Now().Sub(start)).String() + " err" + msg)
-			return schemas, err
+		start = time.Now()
+		database := "catalog"
+		listRecords, err := databaseClient.ListSchemas(context.TODO(), client.SchemaPageQuery{
+			PageQuery: client.PageQuery{
+				PageSize:   pageSize,
+				PageNumber: pageNum,
+			},
+			Database: &database,
 		})
+		msg := ""
+		if err != nil {
+			msg += err.Error()
+		}
+		println("http call cost:" + (time.Now().Sub(start)).String() + " err" + msg)
 		if err != nil {
 			return nil, err
 		}
@@ -58,10 +56,7 @@ func TestScrollDatabase(t *testing.T) {
 	dbClient, _ := client.NewRelytDatabaseClient(relytDatabaseClientConfig)
 	start := time.Now()
 	records, _ := ScrollPageRecords(&diag.Diagnostics{}, func(pageSize, pageNum int) ([]*client.Database, error) {
-		listRecords, err := CommonRetry(context.TODO(), func() (*client.CommonPage[client.Database], error) {
-			database, err := dbClient.ListDatabase(context.TODO(), pageSize, pageNum)
-			return database, err
-		})
+		listRecords, err := dbClient.ListDatabase(context.TODO(), pageSize, pageNum)
 		if err != nil {
 			return nil, err
 		}
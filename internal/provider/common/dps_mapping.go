@@ -0,0 +1,27 @@
+package common
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+// MapDpsLifecycle converts the idle and scaling settings of a dps, shared by relyt_dps and relyt_dps_list.
+func MapDpsLifecycle(dps *client.DpsMode, lifecycle *model.DpsLifecycle) {
+	if dps == nil || lifecycle == nil {
+		return
+	}
+	//api省略false和0，按关闭处理
+	lifecycle.AutoSuspend = types.BoolValue(dps.EnableAutoSuspend != nil && *dps.EnableAutoSuspend)
+	lifecycle.AutoResume = types.BoolValue(dps.EnableAutoResume != nil && *dps.EnableAutoResume)
+	lifecycle.AdaptiveQueryScaling = types.BoolValue(dps.EnableAdaptiveQueryScaling != nil && *dps.EnableAdaptiveQueryScaling)
+	lifecycle.KeepAliveTime = types.Int64Value(0)
+	if dps.KeepAliveTime != nil {
+		lifecycle.KeepAliveTime = types.Int64Value(*dps.KeepAliveTime)
+	}
+	if dps.AqsSpec != nil && dps.AqsSpec.Name != "" {
+		lifecycle.AqsSize = types.StringValue(dps.AqsSpec.Name)
+	} else {
+		lifecycle.AqsSize = types.StringNull()
+	}
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+// EndpointsType is the element type of the endpoints list of a dwsu or a cloud region.
+var EndpointsType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"extensions": types.MapType{
+			ElemType: types.StringType,
+		},
+		"host":     types.StringType,
+		"id":       types.StringType,
+		"open":     types.BoolType,
+		"port":     types.Int32Type,
+		"protocol": types.StringType,
+		"type":     types.StringType,
+		"uri":      types.StringType,
+	},
+}
+
+// MapEndpoints converts api endpoints to the endpoints list, an endpoint without extensions gets a null map.
+func MapEndpoints(ctx context.Context, endpoints []client.Endpoints, diagnostics *diag.Diagnostics) types.List {
+	tfEndPoints := []model.Endpoints{}
+	for _, endpoint := range endpoints {
+		tfEndPoints = append(tfEndPoints, mapEndpoint(ctx, &endpoint, diagnostics))
+	}
+	from, d := types.ListValueFrom(ctx, EndpointsType, tfEndPoints)
+	diagnostics.Append(d...)
+	return from
+}
+
+// MapEndpointByType converts the first endpoint of endpointType to an object, null if the dwsu has no such endpoint.
+func MapEndpointByType(ctx context.Context, endpoints []client.Endpoints, endpointType string, diagnostics *diag.Diagnostics) types.Object {
+	endpoint := EndpointByType(endpoints, endpointType)
+	if endpoint == nil {
+		return types.ObjectNull(EndpointsType.AttrTypes)
+	}
+	from, d := types.ObjectValueFrom(ctx, EndpointsType.AttrTypes, mapEndpoint(ctx, endpoint, diagnostics))
+	diagnostics.Append(d...)
+	return from
+}
+
+func mapEndpoint(ctx context.Context, endpoint *client.Endpoints, diagnostics *diag.Diagnostics) model.Endpoints {
+	tfEndpoint := model.Endpoints{
+		Host:       types.StringValue(endpoint.Host),
+		ID:         types.StringValue(endpoint.ID),
+		Open:       types.BoolValue(endpoint.Open),
+		Port:       types.Int32Value(endpoint.Port),
+		Protocol:   types.StringValue(endpoint.Protocol),
+		Type:       types.StringValue(endpoint.Type),
+		URI:        types.StringValue(endpoint.URI),
+		Extensions: types.MapNull(types.StringType),
+	}
+	if endpoint.Extensions != nil {
+		extensions, d := types.MapValueFrom(ctx, types.StringType, *endpoint.Extensions)
+		diagnostics.Append(d...)
+		tfEndpoint.Extensions = extensions
+	}
+	return tfEndpoint
+}
+
+// EndpointByType returns the first endpoint of endpointType, nil if there is none.
+func EndpointByType(endpoints []client.Endpoints, endpointType string) *client.Endpoints {
+	for i := range endpoints {
+		if endpoints[i].Type == endpointType {
+			return &endpoints[i]
+		}
+	}
+	return nil
+}
+
+// JdbcUrl is the postgresql jdbc url of database on a database endpoint, database may be empty.
+func JdbcUrl(endpoint *client.Endpoints, database string) string {
+	return fmt.Sprintf("jdbc:postgresql://%s:%d/%s", endpoint.Host, endpoint.Port, database)
+}
+
+// PsqlConnectionString is the libpq connection uri of a database endpoint, usable by psql.
+func PsqlConnectionString(endpoint *client.Endpoints) string {
+	return fmt.Sprintf("postgresql://%s:%d/", endpoint.Host, endpoint.Port)
+}
+
+// MapDwsuAudit fills status, creator, owner and timestamps of a dwsu.
+func MapDwsuAudit(dwsu *client.DwsuModel, tfDwsu *model.PlainDwsuModel) {
+	tfDwsu.Status = types.StringValue(dwsu.Status)
+	tfDwsu.Creator = types.StringNull()
+	if dwsu.Creator != nil {
+		tfDwsu.Creator = types.StringValue(dwsu.Creator.Email)
+	}
+	tfDwsu.Owner = types.StringNull()
+	if dwsu.Owner != nil {
+		tfDwsu.Owner = types.StringValue(dwsu.Owner.Email)
+	}
+	tfDwsu.CreateTimestamp = types.Int64Value(dwsu.CreateTimestamp)
+	tfDwsu.UpdateTimestamp = types.Int64Value(dwsu.UpdateTimestamp)
+}
+
+// Boto3AccessInfoType is the element type of the boto3 access infos of a dw user.
+var Boto3AccessInfoType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"access_key_id": types.StringType,
+	"access_key":    types.StringType,
+	"secret_key":    types.StringType,
+}}
+
+// MapBoto3AccessInfos converts the boto3 access infos of a dw user to a list.
+func MapBoto3AccessInfos(ctx context.Context, boto3AccessInfo []*client.Boto3AccessInfo, diagnostics *diag.Diagnostics) types.List {
+	saList := []model.Boto3AccessInfo{}
+	for _, boto3 := range boto3AccessInfo {
+		if boto3 == nil {
+			continue
+		}
+		saList = append(saList, model.Boto3AccessInfo{
+			AccessKeyId: types.StringValue(boto3.AccessKeyId),
+			AccessKey:   types.StringValue(boto3.AccessKey),
+			SecretKey:   types.StringValue(boto3.SecretKey),
+		})
+	}
+	from, d := types.ListValueFrom(ctx, Boto3AccessInfoType, saList)
+	diagnostics.Append(d...)
+	return from
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/model"
+	"testing"
+)
+
+func TestMapEndpoints(t *testing.T) {
+	extensions := map[string]string{"dbName": "relyt"}
+	diagnostics := diag.Diagnostics{}
+	endpoints := MapEndpoints(context.Background(), []client.Endpoints{
+		{ID: "1", Type: "database", Port: 5432, Extensions: &extensions},
+		{ID: "2", Type: "openapi"},
+	}, &diagnostics)
+	if diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diagnostics)
+	}
+	var tfEndpoints []model.Endpoints
+	endpoints.ElementsAs(context.Background(), &tfEndpoints, false)
+	if len(tfEndpoints) != 2 {
+		t.Fatalf("expect 2 endpoints, got %d", len(tfEndpoints))
+	}
+	if v, ok := tfEndpoints[0].Extensions.Elements()["dbName"]; !ok || v.String() != `"relyt"` {
+		t.Errorf("extensions should be kept, got %v", tfEndpoints[0].Extensions)
+	}
+	if !tfEndpoints[1].Extensions.IsNull() {
+		t.Errorf("missing extensions should be null, got %v", tfEndpoints[1].Extensions)
+	}
+}
+
+func TestMapDwsuAudit(t *testing.T) {
+	tfDwsu := model.PlainDwsuModel{}
+	MapDwsuAudit(&client.DwsuModel{Status: "READY", Creator: &client.Creator{Email: "a@relyt.cn"}, CreateTimestamp: 1000}, &tfDwsu)
+	if tfDwsu.Status.ValueString() != "READY" || tfDwsu.Creator.ValueString() != "a@relyt.cn" || tfDwsu.CreateTimestamp.ValueInt64() != 1000 {
+		t.Errorf("unexpected audit mapping: %+v", tfDwsu)
+	}
+	if !tfDwsu.Owner.IsNull() {
+		t.Errorf("missing owner should be null, got %v", tfDwsu.Owner)
+	}
+}
+
+func TestEndpointByType(t *testing.T) {
+	endpoints := []client.Endpoints{
+		{ID: "1", Type: client.ENDPOINT_TYPE_OPENAPI, Host: "api.relyt.cn", Port: 443},
+		{ID: "2", Type: client.ENDPOINT_TYPE_DATABASE, Host: "db.relyt.cn", Port: 5432},
+	}
+	endpoint := EndpointByType(endpoints, client.ENDPOINT_TYPE_DATABASE)
+	if endpoint == nil || endpoint.ID != "2" {
+		t.Fatalf("expect database endpoint 2, got %v", endpoint)
+	}
+	if url := JdbcUrl(endpoint, ""); url != "jdbc:postgresql://db.relyt.cn:5432/" {
+		t.Errorf("unexpected jdbc url: %s", url)
+	}
+	if conn := PsqlConnectionString(endpoint); conn != "postgresql://db.relyt.cn:5432/" {
+		t.Errorf("unexpected psql connection string: %s", conn)
+	}
+	diagnostics := diag.Diagnostics{}
+	if !MapEndpointByType(context.Background(), endpoints, client.ENDPOINT_TYPE_WEB_CONSOLE, &diagnostics).IsNull() {
+		t.Errorf("missing web console endpoint should be null")
+	}
+	if MapEndpointByType(context.Background(), endpoints, client.ENDPOINT_TYPE_OPENAPI, &diagnostics).IsNull() || diagnostics.HasError() {
+		t.Errorf("openapi endpoint should be mapped, got %v", diagnostics)
+	}
+}
+
+func TestMapBoto3AccessInfos(t *testing.T) {
+	diagnostics := diag.Diagnostics{}
+	infos := MapBoto3AccessInfos(context.Background(), []*client.Boto3AccessInfo{
+		{AccessKeyId: "1", AccessKey: "ak", SecretKey: "sk"}, nil,
+	}, &diagnostics)
+	if diagnostics.HasError() || len(infos.Elements()) != 1 {
+		t.Errorf("expect 1 access info, got %v %v", infos, diagnostics)
+	}
+	if infos = MapBoto3AccessInfos(context.Background(), nil, &diagnostics); infos.IsNull() || len(infos.Elements()) != 0 {
+		t.Errorf("no access info should be an empty list, got %v", infos)
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// ParseDpsImportId splits the dps import id dwsu_id,dps_id.
+func ParseDpsImportId(id string) (dwsuId, dpsId string, err error) {
+	idParts := strings.Split(id, ",")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("Expected import identifier with format: dwsu_id,dps_id. Got: %q", id)
+	}
+	return idParts[0], idParts[1], nil
+}
+
+// ExternalSchemaId is the import id of an external schema, database,catalog,name, or the base64 form when a part
+// contains a comma.
+func ExternalSchemaId(database, catalog, name string) string {
+	ids := []string{database, catalog, name}
+	if !strings.Contains(database+catalog+name, ",") {
+		return strings.Join(ids, ",")
+	}
+	for i, id := range ids {
+		ids[i] = base64.StdEncoding.EncodeToString([]byte(id))
+	}
+	return "base64," + strings.Join(ids, ",")
+}
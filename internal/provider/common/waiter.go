@@ -0,0 +1,155 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"time"
+)
+
+const (
+	defaultMinPollInterval = 2 * time.Second
+	// defaultNotFoundChecks tolerates a freshly created object that isn't visible to the read api yet.
+	defaultNotFoundChecks = 3
+)
+
+// RefreshFunc returns the object being waited on and its status. A nil object, or a not found api
+// error, means the object doesn't exist.
+type RefreshFunc[T any] func(ctx context.Context) (*T, string, error)
+
+// Waiter polls Refresh until the object reaches one of the Target statuses, similar to
+// StateChangeConf of the sdk. The first check runs immediately, then the poll interval grows from
+// MinInterval up to MaxInterval.
+type Waiter[T any] struct {
+	// Pending statuses keep waiting, any other status not in Target is unexpected. An empty Pending
+	// keeps waiting on every status not in Target or Failed.
+	Pending []string
+	Target  []string
+	// Failed statuses stop the wait immediately with an UnexpectedStateError.
+	Failed  []string
+	Refresh RefreshFunc[T]
+	Timeout time.Duration
+	// MinInterval and MaxInterval bound the wait between two checks.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// NotFoundChecks is the number of consecutive checks allowed to find nothing before failing.
+	NotFoundChecks int
+	// TargetNotFound makes a missing object reach the target, used by delete waits.
+	TargetNotFound bool
+}
+
+// TimeoutError is returned when Target isn't reached within Timeout.
+type TimeoutError struct {
+	LastStatus string
+	Target     []string
+	Timeout    time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout after %s waiting for status %s, last status: %s",
+		e.Timeout, describeStatus(e.Target), e.LastStatus)
+}
+
+// UnexpectedStateError is returned when the object enters a status that will never reach Target.
+type UnexpectedStateError struct {
+	Status string
+	Target []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected status %s while waiting for status %s", e.Status, describeStatus(e.Target))
+}
+
+// NotFoundError is returned when the object doesn't exist for more than NotFoundChecks checks.
+type NotFoundError struct {
+	Checks int
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("object not found after %d checks", e.Checks)
+}
+
+func describeStatus(status []string) string {
+	if len(status) == 0 {
+		return "deleted"
+	}
+	return strings.Join(status, "|")
+}
+
+// DefaultTimeout is the provider resource_check_timeout, used when a resource has no timeouts block.
+func DefaultTimeout(relytClient *client.RelytClient) time.Duration {
+	return time.Duration(relytClient.CheckTimeOut) * time.Second
+}
+
+// NewWaiter creates a Waiter polling with the provider resource_check_interval.
+func NewWaiter[T any](relytClient *client.RelytClient, timeout time.Duration, refresh RefreshFunc[T]) *Waiter[T] {
+	maxInterval := time.Duration(relytClient.CheckInterval) * time.Second
+	return &Waiter[T]{
+		Refresh:        refresh,
+		Timeout:        timeout,
+		MinInterval:    min(defaultMinPollInterval, maxInterval),
+		MaxInterval:    maxInterval,
+		NotFoundChecks: defaultNotFoundChecks,
+	}
+}
+
+// Wait blocks until the target is reached, ctx is done, Timeout expires or an unexpected status is seen.
+func (w *Waiter[T]) Wait(ctx context.Context) (*T, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+	timeoutErr := func(lastStatus string) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &TimeoutError{LastStatus: lastStatus, Target: w.Target, Timeout: w.Timeout}
+	}
+
+	interval := w.MinInterval
+	if interval <= 0 {
+		interval = defaultMinPollInterval
+	}
+	notFound := 0
+	lastStatus := ""
+	for {
+		result, status, err := w.Refresh(timeoutCtx)
+		if client.IsNotFound(err) {
+			result, err = nil, nil
+		}
+		if err != nil {
+			if timeoutCtx.Err() != nil {
+				return nil, timeoutErr(lastStatus)
+			}
+			return nil, err
+		}
+		if result == nil {
+			if w.TargetNotFound {
+				return nil, nil
+			}
+			notFound++
+			if notFound > w.NotFoundChecks {
+				return nil, &NotFoundError{Checks: notFound}
+			}
+		} else {
+			notFound = 0
+			lastStatus = status
+			if slices.Contains(w.Target, status) {
+				return result, nil
+			}
+			if slices.Contains(w.Failed, status) || (len(w.Pending) > 0 && !slices.Contains(w.Pending, status)) {
+				return result, &UnexpectedStateError{Status: status, Target: w.Target}
+			}
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-timeoutCtx.Done():
+			timer.Stop()
+			return result, timeoutErr(lastStatus)
+		case <-timer.C:
+		}
+		if interval *= 2; w.MaxInterval > 0 && interval > w.MaxInterval {
+			interval = w.MaxInterval
+		}
+	}
+}
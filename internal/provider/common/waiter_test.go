@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"terraform-provider-relyt/internal/provider/client"
+	"testing"
+	"time"
+)
+
+type waitObject struct {
+	status string
+}
+
+func sequence(statuses ...string) RefreshFunc[waitObject] {
+	i := 0
+	return func(ctx context.Context) (*waitObject, string, error) {
+		status := statuses[min(i, len(statuses)-1)]
+		i++
+		if status == "" {
+			return nil, "", &client.APIError{HttpStatus: 404}
+		}
+		return &waitObject{status: status}, status, nil
+	}
+}
+
+func TestWaiter_target(t *testing.T) {
+	w := &Waiter[waitObject]{Target: []string{"READY"}, Pending: []string{"CREATING"}, NotFoundChecks: 1,
+		Timeout: time.Second, MinInterval: time.Millisecond, Refresh: sequence("", "CREATING", "READY")}
+	result, err := w.Wait(context.Background())
+	if err != nil || result == nil || result.status != "READY" {
+		t.Errorf("expected READY, got %v %v", result, err)
+	}
+}
+
+func TestWaiter_unexpected(t *testing.T) {
+	w := &Waiter[waitObject]{Target: []string{"READY"}, Failed: []string{"DROPPED"},
+		Timeout: time.Second, MinInterval: time.Millisecond, Refresh: sequence("CREATING", "DROPPED")}
+	_, err := w.Wait(context.Background())
+	var unexpected *UnexpectedStateError
+	if !errors.As(err, &unexpected) || unexpected.Status != "DROPPED" {
+		t.Errorf("expected unexpected state error, got %v", err)
+	}
+
+	w = &Waiter[waitObject]{Target: []string{"READY"}, Timeout: time.Second, MinInterval: time.Millisecond, Refresh: sequence("")}
+	_, err = w.Wait(context.Background())
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestWaiter_deleted(t *testing.T) {
+	w := &Waiter[waitObject]{TargetNotFound: true, Timeout: time.Second, MinInterval: time.Millisecond, Refresh: sequence("DROPPING", "")}
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Errorf("missing object should finish a delete wait, got %v", err)
+	}
+}
+
+func TestWaiter_timeout(t *testing.T) {
+	w := &Waiter[waitObject]{Target: []string{"READY"}, Timeout: 50 * time.Millisecond,
+		MinInterval: 10 * time.Millisecond, MaxInterval: 20 * time.Millisecond, Refresh: sequence("CREATING")}
+	_, err := w.Wait(context.Background())
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) || timeout.LastStatus != "CREATING" {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w.Timeout = time.Minute
+	if _, err = w.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled wait should return ctx error, got %v", err)
+	}
+}
@@ -3,8 +3,6 @@ package datasource
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
-	types "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
@@ -34,6 +32,7 @@ func (d *Boto3DataSource) Metadata(ctx context.Context, req datasource.MetadataR
 // Schema defines the schema for the data source.
 func (d *Boto3DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		DeprecationMessage: "The access keys read by this data source are stored in state. Use the relyt_dwsu_boto3_access_info ephemeral resource instead.",
 		Attributes: map[string]schema.Attribute{
 			"dwsu_id":    schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
 			"account_id": schema.StringAttribute{Required: true, Description: "The ID of the account"},
@@ -42,7 +41,7 @@ func (d *Boto3DataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					Attributes: map[string]schema.Attribute{
 						"access_key_id": schema.StringAttribute{Computed: true, Description: "The ID of the key"},
 						"access_key":    schema.StringAttribute{Computed: true, Description: "AccessKey"},
-						"secret_key":    schema.StringAttribute{Computed: true, Description: "SecretKey"},
+						"secret_key":    schema.StringAttribute{Computed: true, Sensitive: true, Description: "SecretKey"},
 					},
 				},
 				Computed: true,
@@ -63,33 +62,15 @@ func (d *Boto3DataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	boto3AccessInfo, err := common.CommonRetry(ctx, func() (*[]*client.Boto3AccessInfo, error) {
-		info, err := d.client.GetBoto3AccessInfo(ctx, meta.URI, state.DwsuId.ValueString(), state.DwUserId.ValueString())
-		return &info, err
-	})
+	boto3AccessInfo, err := d.client.GetBoto3AccessInfo(ctx, meta.URI, state.DwsuId.ValueString(), state.DwUserId.ValueString())
 
 	if err != nil {
 		tflog.Error(ctx, "error read boto3 access info:"+err.Error())
 		resp.Diagnostics.AddError("read failed!", "error read boto3:"+err.Error())
 		return
 	}
-	if boto3AccessInfo != nil && len(*boto3AccessInfo) > 0 {
-		var saList []model.Boto3AccessInfo
-		for _, boto3 := range *boto3AccessInfo {
-			saList = append(saList, model.Boto3AccessInfo{
-				AccessKeyId: types.StringValue(boto3.AccessKeyId),
-				AccessKey:   types.StringValue(boto3.AccessKey),
-				SecretKey:   types.StringValue(boto3.SecretKey),
-			})
-		}
-		saListType := types.ObjectType{AttrTypes: map[string]attr.Type{
-			"access_key_id": types.StringType,
-			"access_key":    types.StringType,
-			"secret_key":    types.StringType,
-		}}
-		from, diagnostics := types.ListValueFrom(ctx, saListType, saList)
-		resp.Diagnostics.Append(diagnostics...)
-		state.Boto3AccessInfos = from
+	if len(boto3AccessInfo) > 0 {
+		state.Boto3AccessInfos = common.MapBoto3AccessInfos(ctx, boto3AccessInfo, &resp.Diagnostics)
 	}
 	// Set state
 	diags = resp.State.Set(ctx, state)
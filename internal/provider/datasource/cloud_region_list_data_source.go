@@ -4,8 +4,6 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
-	"terraform-provider-relyt/internal/provider/client"
-	"terraform-provider-relyt/internal/provider/common"
 	"terraform-provider-relyt/internal/provider/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -77,9 +75,7 @@ func (d *CloudRegionEndPointListDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 
-	regionEndpoints, err := common.CommonRetry(ctx, func() (*[]client.RegionEndpoint, error) {
-		return d.client.GetRegionEndpoints(ctx, state.Cloud.ValueString(), state.Region.ValueString())
-	})
+	regionEndpoints, err := d.client.GetRegionEndpoints(ctx, state.Cloud.ValueString(), state.Region.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("error read cloud region endpoints", "msg: "+err.Error())
 		//tflog.Error(ctx, "error read dwsu"+err.Error())
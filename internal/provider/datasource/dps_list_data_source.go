@@ -0,0 +1,127 @@
+package datasource
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var (
+	_ datasource.DataSource              = &DpsListDataSource{}
+	_ datasource.DataSourceWithConfigure = &DpsListDataSource{}
+)
+
+func NewDpsListDataSource() datasource.DataSource {
+	return &DpsListDataSource{}
+}
+
+type DpsListDataSource struct {
+	RelytClientDatasource
+}
+
+func (d *DpsListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dps_list"
+}
+
+// Schema defines the schema for the data source.
+func (d *DpsListDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"dwsu_id": schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
+			"name":    schema.StringAttribute{Optional: true, Description: "Only return the DPS cluster with this name."},
+			"engine":  schema.StringAttribute{Optional: true, Description: "Only return the DPS clusters of this type. hybrid, extreme, vector"},
+			"status":  schema.StringAttribute{Optional: true, Description: "Only return the DPS clusters in this status."},
+			"dps_list": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The DPS clusters of the service unit.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dpsAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// dpsAttributes are the computed attributes of a dps shared by relyt_dps_list and the default dps of the dwsu data sources.
+func dpsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id":                     schema.StringAttribute{Computed: true, Description: "The ID of the DPS cluster."},
+		"name":                   schema.StringAttribute{Computed: true, Description: "The name of the DPS cluster."},
+		"description":            schema.StringAttribute{Computed: true, Description: "The description of the DPS cluster."},
+		"engine":                 schema.StringAttribute{Computed: true, Description: "The type of the DPS cluster."},
+		"size":                   schema.StringAttribute{Computed: true, Description: "The name of the DPS cluster specification."},
+		"status":                 schema.StringAttribute{Computed: true, Description: "The status of the DPS cluster."},
+		"auto_suspend":           schema.BoolAttribute{Computed: true, Description: "Whether the DPS cluster is suspended automatically after being idle for keep_alive_time."},
+		"auto_resume":            schema.BoolAttribute{Computed: true, Description: "Whether a suspended DPS cluster is resumed automatically by incoming queries."},
+		"keep_alive_time":        schema.Int64Attribute{Computed: true, Description: "The idle time before the DPS cluster is suspended automatically."},
+		"adaptive_query_scaling": schema.BoolAttribute{Computed: true, Description: "Whether adaptive query scaling is enabled for the DPS cluster."},
+		"aqs_size":               schema.StringAttribute{Computed: true, Description: "The name of the specification adaptive query scaling scales out with."},
+		"creator":                schema.StringAttribute{Computed: true, Description: "The email of the user who created the DPS cluster."},
+		"create_time":            schema.Int64Attribute{Computed: true, Description: "The time the DPS cluster was created, in unix milliseconds."},
+		"update_time":            schema.Int64Attribute{Computed: true, Description: "The time the DPS cluster was last updated, in unix milliseconds."},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DpsListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state model.DpsList
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.DwsuId.ValueString() == "" {
+		resp.Diagnostics.AddError("parameter error", "dwsu_id can't be empty")
+		return
+	}
+	relytDpsList, _ := common.ScrollPageRecords(&resp.Diagnostics,
+		func(pageSize, pageNum int) ([]*client.DpsMode, error) {
+			return d.client.ListDps(ctx, pageSize, pageNum, state.DwsuId.ValueString())
+		})
+	//ScrollPageRecords已经写入了错误
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.DpsList = []model.DpsListItem{}
+	for _, dps := range relytDpsList {
+		if dps == nil || !matchFilter(state.Name, dps.Name) || !matchFilter(state.Engine, dps.Engine) || !matchFilter(state.Status, dps.Status) {
+			continue
+		}
+		state.DpsList = append(state.DpsList, mapDpsListItem(dps))
+	}
+	tflog.Info(ctx, "dps list size: "+strconv.Itoa(len(state.DpsList)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// matchFilter is true when the filter isn't set or equals value.
+func matchFilter(filter types.String, value string) bool {
+	return filter.IsNull() || filter.IsUnknown() || filter.ValueString() == value
+}
+
+func mapDpsListItem(dps *client.DpsMode) model.DpsListItem {
+	item := model.DpsListItem{
+		ID:          types.StringValue(dps.ID),
+		Name:        types.StringValue(dps.Name),
+		Description: types.StringValue(dps.Description),
+		Engine:      types.StringValue(dps.Engine),
+		Size:        types.StringNull(),
+		Status:      types.StringValue(dps.Status),
+		Creator:     types.StringNull(),
+		CreateTime:  types.Int64Value(dps.CreateTime),
+		UpdateTime:  types.Int64Value(dps.UpdateTime),
+	}
+	if dps.Spec != nil {
+		item.Size = types.StringValue(dps.Spec.Name)
+	}
+	if dps.Creator != nil {
+		item.Creator = types.StringValue(dps.Creator.Email)
+	}
+	common.MapDpsLifecycle(dps, &item.DpsLifecycle)
+	return item
+}
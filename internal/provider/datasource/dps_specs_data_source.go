@@ -0,0 +1,112 @@
+package datasource
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var (
+	_ datasource.DataSource              = &DpsSpecsDataSource{}
+	_ datasource.DataSourceWithConfigure = &DpsSpecsDataSource{}
+)
+
+// defaultEdition is the edition a dwsu is created with when edition isn't set.
+const defaultEdition = "standard"
+
+func NewDpsSpecsDataSource() datasource.DataSource {
+	return &DpsSpecsDataSource{}
+}
+
+type DpsSpecsDataSource struct {
+	RelytClientDatasource
+}
+
+func (d *DpsSpecsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dps_specs"
+}
+
+// Schema defines the schema for the data source.
+func (d *DpsSpecsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"edition": schema.StringAttribute{Optional: true, Computed: true, Description: "The ID of the edition. Defaults standard"},
+			"engine": schema.StringAttribute{Required: true, Description: "The type of the DPS cluster. hybrid, extreme, vector",
+				Validators: []validator.String{stringvalidator.OneOf(client.DPS_ENGINE_HYBRID, client.DPS_ENGINE_EXTREME, client.DPS_ENGINE_VECTOR)}},
+			"cloud":  schema.StringAttribute{Required: true, Description: "The ID of the cloud provider."},
+			"region": schema.StringAttribute{Required: true, Description: "The ID of the region."},
+			"names":  schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "The names of the specifications, used as the size of a DPS cluster."},
+			"specs": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The specifications.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":   schema.Int64Attribute{Computed: true, Description: "The ID of the specification."},
+						"name": schema.StringAttribute{Computed: true, Description: "The name of the specification."},
+						"usage_rates": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The usage rates of the specification.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"amount": schema.Float64Attribute{Computed: true, Description: "The amount charged per unit."},
+									"type":   schema.StringAttribute{Computed: true, Description: "The type of the usage rate."},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DpsSpecsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state model.DpsSpecs
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.Edition.IsNull() {
+		state.Edition = types.StringValue(defaultEdition)
+	}
+	if state.Cloud.ValueString() == "" {
+		resp.Diagnostics.AddError("parameter error", "cloud can't be empty")
+	}
+	if state.Region.ValueString() == "" {
+		resp.Diagnostics.AddError("parameter error", "region can't be empty")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	specs, err := d.client.ListSpec(ctx, state.Edition.ValueString(), state.Engine.ValueString(), state.Cloud.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("error read dps specs", "msg: "+err.Error())
+		return
+	}
+	state.Names = []string{}
+	state.Specs = []model.DpsSpec{}
+	for _, spec := range specs {
+		tfSpec := model.DpsSpec{
+			ID:         types.Int64Value(spec.ID),
+			Name:       types.StringValue(spec.Name),
+			UsageRates: []model.UsageRate{},
+		}
+		for _, rate := range spec.UsageRates {
+			tfSpec.UsageRates = append(tfSpec.UsageRates, model.UsageRate{
+				Amount: types.Float64Value(rate.Amount),
+				Type:   types.StringValue(rate.Type),
+			})
+		}
+		state.Names = append(state.Names, spec.Name)
+		state.Specs = append(state.Specs, tfSpec)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,118 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var (
+	_ datasource.DataSource                     = &DwsuDataSource{}
+	_ datasource.DataSourceWithConfigure        = &DwsuDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &DwsuDataSource{}
+)
+
+func NewDwsuDataSource() datasource.DataSource {
+	return &DwsuDataSource{}
+}
+
+// DwsuDataSource looks up one dwsu by id, domain or alias.
+type DwsuDataSource struct {
+	RelytClientDatasource
+}
+
+func (d *DwsuDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dwsu"
+}
+
+// Schema defines the schema for the data source.
+func (d *DwsuDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dwsuAttributes()
+	attributes["id"] = schema.StringAttribute{Optional: true, Computed: true, Description: "The ID of the service unit."}
+	attributes["domain"] = schema.StringAttribute{Optional: true, Computed: true, Description: "The domain name of the service unit."}
+	attributes["alias"] = schema.StringAttribute{Optional: true, Computed: true, Description: "The alias of the service unit."}
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (d *DwsuDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("domain"), path.MatchRoot("alias")),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DwsuDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config model.DwsuDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var dwsu *client.DwsuModel
+	if !config.ID.IsNull() {
+		relytDwsu, err := d.client.GetDwsu(ctx, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("error read dwsu", "msg: "+err.Error())
+			return
+		}
+		if relytDwsu == nil || relytDwsu.Status == client.DPS_STATUS_DROPPED {
+			resp.Diagnostics.AddAttributeError(path.Root("id"), "dwsu not found", "no dwsu found with id "+config.ID.ValueString())
+			return
+		}
+		dwsu = relytDwsu
+	} else {
+		dwsu = d.lookupDwsu(ctx, &config, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	state := mapDwsuDataModel(ctx, dwsu, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// lookupDwsu finds the only dwsu matching domain or alias in the dwsu list.
+func (d *DwsuDataSource) lookupDwsu(ctx context.Context, config *model.DwsuDataModel, resp *datasource.ReadResponse) *client.DwsuModel {
+	attribute, value := "domain", config.Domain.ValueString()
+	if config.Domain.IsNull() {
+		attribute, value = "alias", config.Alias.ValueString()
+	}
+	relytDwsuList, _ := common.ScrollPageRecords(&resp.Diagnostics,
+		func(pageSize, pageNum int) ([]*client.DwsuModel, error) {
+			return d.client.ListDwsu(ctx, pageSize, pageNum)
+		})
+	//ScrollPageRecords已经写入了错误
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+	var matched []*client.DwsuModel
+	for _, dwsu := range relytDwsuList {
+		if dwsu == nil || dwsu.Status == client.DPS_STATUS_DROPPED {
+			continue
+		}
+		if (attribute == "domain" && dwsu.Domain == value) || (attribute == "alias" && dwsu.Alias == value) {
+			matched = append(matched, dwsu)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "dwsu not found", fmt.Sprintf("no dwsu found with %s %s", attribute, value))
+		return nil
+	case 1:
+		return matched[0]
+	}
+	ids := make([]string, 0, len(matched))
+	for _, dwsu := range matched {
+		ids = append(ids, dwsu.ID)
+	}
+	resp.Diagnostics.AddAttributeError(path.Root(attribute), "multiple dwsu found",
+		fmt.Sprintf("%d dwsu found with %s %s: %s! Please look up by id instead.", len(matched), attribute, value, strings.Join(ids, ", ")))
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
 	"terraform-provider-relyt/internal/provider/model"
 )
@@ -47,9 +46,7 @@ func (d *DwsuDatabaseDetailDataSource) Read(ctx context.Context, req datasource.
 	tfDatabase := model.DwsuDatabaseMeta{}
 	diags := req.Config.Get(ctx, &tfDatabase)
 	resp.Diagnostics.Append(diags...)
-	database, err := common.CommonRetry(ctx, func() (*client.Database, error) {
-		return dbClient.GetDatabase(ctx, tfDatabase.Name.ValueString())
-	})
+	database, err := dbClient.GetDatabase(ctx, tfDatabase.Name.ValueString())
 	if err != nil {
 		msg := "database read failed"
 		if err != nil {
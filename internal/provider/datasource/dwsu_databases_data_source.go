@@ -56,10 +56,7 @@ func (d *DwsuDatabasesDataSource) Read(ctx context.Context, req datasource.ReadR
 	diags := req.Config.Get(ctx, &tfDatabases)
 	resp.Diagnostics.Append(diags...)
 	records, _ := common.ScrollPageRecords(&resp.Diagnostics, func(pageSize, pageNum int) ([]*client.Database, error) {
-		listRecords, err := common.CommonRetry(ctx, func() (*client.CommonPage[client.Database], error) {
-			database, err := dbClient.ListDatabase(ctx, pageSize, pageNum)
-			return database, err
-		})
+		listRecords, err := dbClient.ListDatabase(ctx, pageSize, pageNum)
 		if err != nil {
 			return nil, err
 		}
@@ -51,12 +51,10 @@ func (d *DwsuSchemaDetailDataSource) Read(ctx context.Context, req datasource.Re
 	diags := req.Config.Get(ctx, &tfSchema)
 	resp.Diagnostics.Append(diags...)
 
-	schemaMeta, err := common.CommonRetry(ctx, func() (*client.SchemaMeta, error) {
-		return dbClient.GetExternalSchema(ctx, client.Schema{
-			Database: tfSchema.Database.ValueStringPointer(),
-			Catalog:  tfSchema.Catalog.ValueStringPointer(),
-			Name:     tfSchema.Name.ValueStringPointer(),
-		})
+	schemaMeta, err := dbClient.GetExternalSchema(ctx, client.Schema{
+		Database: tfSchema.Database.ValueStringPointer(),
+		Catalog:  tfSchema.Catalog.ValueStringPointer(),
+		Name:     tfSchema.Name.ValueStringPointer(),
 	})
 	if err != nil {
 		msg := "schema read failed"
@@ -2,9 +2,10 @@ package datasource
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
 	"strconv"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
@@ -36,36 +37,15 @@ func (d *DwsuListDataSource) Metadata(ctx context.Context, req datasource.Metada
 func (d *DwsuListDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"cloud":   schema.StringAttribute{Optional: true, Description: "Only return the service units of this cloud provider."},
+			"region":  schema.StringAttribute{Optional: true, Description: "Only return the service units in this region."},
+			"edition": schema.StringAttribute{Optional: true, Description: "Only return the service units of this edition."},
+			"status":  schema.StringAttribute{Optional: true, Description: "Only return the service units in this status."},
+			"tags":    schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Only return the service units having all of these tags."},
 			"dwsu_list": schema.ListNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
 					//resource与database定义所引用的schema是不一样的。。。。所以此处无法复用
-					Attributes: map[string]schema.Attribute{
-						"id":      schema.StringAttribute{Computed: true, Description: "The ID of the service unit."},
-						"cloud":   schema.StringAttribute{Required: true, Description: "The ID of the cloud provider."},
-						"region":  schema.StringAttribute{Required: true, Description: "The ID of the region."},
-						"domain":  schema.StringAttribute{Required: true, Description: "The domain name of the service unit."},
-						"variant": schema.StringAttribute{Optional: true, Computed: true, Description: "The variables."},
-						"edition": schema.StringAttribute{Optional: true, Computed: true, Description: "The ID of the edition."},
-						"alias":   schema.StringAttribute{Optional: true, Description: "The alias of the service unit."},
-						"endpoints": schema.ListNestedAttribute{
-							Computed:    true,
-							Description: "endpoints of dwsu",
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"extensions": schema.MapAttribute{Computed: true,
-										ElementType: types.StringType,
-										Description: "extension info of endpoint"},
-									"host":     schema.StringAttribute{Computed: true, Description: "The name of the host used by the endpoint."},
-									"id":       schema.StringAttribute{Computed: true, Description: "The ID of the endpoint."},
-									"open":     schema.BoolAttribute{Computed: true, Description: "Public network access"},
-									"port":     schema.Int64Attribute{Computed: true, Description: "The port number used by the endpoint."},
-									"protocol": schema.StringAttribute{Computed: true, Description: "The protocol used by the endpoint. enum: {HTTP, HTTPS, JDBC}"},
-									"type":     schema.StringAttribute{Computed: true, Description: "The type of the endpoint. enum: {openapi, web_console, database}"},
-									"uri":      schema.StringAttribute{Computed: true, Description: "The URI of the endpoint."},
-								},
-							},
-						},
-					},
+					Attributes: dwsuAttributes(),
 				},
 				Description: "The dwsu list",
 				Computed:    true,
@@ -74,90 +54,128 @@ func (d *DwsuListDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 	}
 }
 
+// dwsuAttributes are the computed attributes of a dwsu shared by relyt_dwsus and relyt_dwsu.
+func dwsuAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id":               schema.StringAttribute{Computed: true, Description: "The ID of the service unit."},
+		"cloud":            schema.StringAttribute{Computed: true, Description: "The ID of the cloud provider."},
+		"region":           schema.StringAttribute{Computed: true, Description: "The ID of the region."},
+		"domain":           schema.StringAttribute{Computed: true, Description: "The domain name of the service unit."},
+		"variant":          schema.StringAttribute{Computed: true, Description: "The variables."},
+		"edition":          schema.StringAttribute{Computed: true, Description: "The ID of the edition."},
+		"alias":            schema.StringAttribute{Computed: true, Description: "The alias of the service unit."},
+		"status":           schema.StringAttribute{Computed: true, Description: "The status of the service unit."},
+		"creator":          schema.StringAttribute{Computed: true, Description: "The email of the user who created the service unit."},
+		"owner":            schema.StringAttribute{Computed: true, Description: "The email of the owner of the service unit."},
+		"create_timestamp": schema.Int64Attribute{Computed: true, Description: "The time the service unit was created, in unix milliseconds."},
+		"update_timestamp": schema.Int64Attribute{Computed: true, Description: "The time the service unit was last updated, in unix milliseconds."},
+		"default_dps": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The default DPS cluster of the service unit.",
+			Attributes:  dpsAttributes(),
+		},
+		"endpoints": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "endpoints of dwsu",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"extensions": schema.MapAttribute{Computed: true,
+						ElementType: types.StringType,
+						Description: "extension info of endpoint"},
+					"host":     schema.StringAttribute{Computed: true, Description: "The name of the host used by the endpoint."},
+					"id":       schema.StringAttribute{Computed: true, Description: "The ID of the endpoint."},
+					"open":     schema.BoolAttribute{Computed: true, Description: "Public network access"},
+					"port":     schema.Int64Attribute{Computed: true, Description: "The port number used by the endpoint."},
+					"protocol": schema.StringAttribute{Computed: true, Description: "The protocol used by the endpoint. enum: {HTTP, HTTPS, JDBC}"},
+					"type":     schema.StringAttribute{Computed: true, Description: "The type of the endpoint. enum: {openapi, web_console, database}"},
+					"uri":      schema.StringAttribute{Computed: true, Description: "The URI of the endpoint."},
+				},
+			},
+		},
+	}
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *DwsuListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config model.DwsuListModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var tags []string
+	if !config.Tags.IsNull() && !config.Tags.IsUnknown() {
+		resp.Diagnostics.Append(config.Tags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	relytDwsuList, err := common.ScrollPageRecords(&resp.Diagnostics,
+	relytDwsuList, _ := common.ScrollPageRecords(&resp.Diagnostics,
 		func(pageSize, pageNum int) ([]*client.DwsuModel, error) {
 			return d.client.ListDwsu(ctx, pageSize, pageNum)
 		})
-	if err != nil {
-		resp.Diagnostics.AddError("error read dwsu list", "msg: "+err.Error())
-		//tflog.Error(ctx, "error read dwsu"+err.Error())
+	//ScrollPageRecords已经写入了错误
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	endpointsTFType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"extensions": types.MapType{
-				ElemType: types.StringType,
-			},
-			"host":     types.StringType,
-			"id":       types.StringType,
-			"open":     types.BoolType,
-			"port":     types.Int32Type,
-			"protocol": types.StringType,
-			"type":     types.StringType,
-			"uri":      types.StringType,
-		},
+	config.DwsuList = []model.DwsuDataModel{}
+	for _, dwsuModel := range relytDwsuList {
+		if dwsuModel == nil || !matchDwsu(&config, tags, dwsuModel) {
+			continue
+		}
+		config.DwsuList = append(config.DwsuList, mapDwsuDataModel(ctx, dwsuModel, &resp.Diagnostics))
 	}
-	dwsuModelList := []model.PlainDwsuModel{}
-	if len(relytDwsuList) > 0 {
-		for _, dwsuModel := range relytDwsuList {
-			slice := model.PlainDwsuModel{
-				ID:     types.StringValue(dwsuModel.ID),
-				Alias:  types.StringValue(dwsuModel.Alias),
-				Domain: types.StringValue(dwsuModel.Domain),
-			}
-			if dwsuModel.Region.Cloud != nil {
-				slice.Cloud = types.StringValue(dwsuModel.Region.Cloud.ID)
-				slice.Region = types.StringValue(dwsuModel.Region.ID)
-			}
-			if dwsuModel.Variant != nil {
-				slice.Variant = types.StringValue(dwsuModel.Variant.ID)
-			}
-			if dwsuModel.Edition != nil {
-				slice.Edition = types.StringValue(dwsuModel.Edition.ID)
-			}
+	tflog.Info(ctx, "convert list size{}"+strconv.Itoa(len(config.DwsuList)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
 
-			var tfEndPoints []model.Endpoints
-			for _, endpoint := range dwsuModel.Endpoints {
-				tfEndpoint := model.Endpoints{
-					//Extensions: types.MapValue(types.StringType),
-					Host:       types.StringValue(endpoint.Host),
-					ID:         types.StringValue(endpoint.ID),
-					Open:       types.BoolValue(endpoint.Open),
-					Port:       types.Int32Value(endpoint.Port),
-					Protocol:   types.StringValue(endpoint.Protocol),
-					Type:       types.StringValue(endpoint.Type),
-					URI:        types.StringValue(endpoint.URI),
-					Extensions: types.MapNull(types.StringType),
-				}
-				//mapValue, diage := types.MapValueFrom(ctx, types.StringType, endpoint.Extensions)
-				//resp.Diagnostics.Append(diage...)
-				tfEndPoints = append(tfEndPoints, tfEndpoint)
-			}
-			from, d := types.ListValueFrom(ctx, endpointsTFType, tfEndPoints)
-			resp.Diagnostics.Append(d...)
-			slice.Endpoints = from
-			dwsuModelList = append(dwsuModelList, slice)
+// matchDwsu checks the dwsu against the filters of relyt_dwsus.
+func matchDwsu(config *model.DwsuListModel, tags []string, dwsu *client.DwsuModel) bool {
+	cloud, region, edition := "", "", ""
+	if dwsu.Region != nil {
+		region = dwsu.Region.ID
+		if dwsu.Region.Cloud != nil {
+			cloud = dwsu.Region.Cloud.ID
 		}
 	}
-	DwsuModelType := types.ObjectType{AttrTypes: map[string]attr.Type{
-		"id":        types.StringType,
-		"cloud":     types.StringType,
-		"region":    types.StringType,
-		"domain":    types.StringType,
-		"variant":   types.StringType,
-		"edition":   types.StringType,
-		"alias":     types.StringType,
-		"endpoints": types.ListType{ElemType: endpointsTFType},
-	}}
-	tflog.Info(ctx, "convert list size{}"+strconv.Itoa(len(dwsuModelList)))
-	dwsuList, diagnostics := types.ListValueFrom(ctx, DwsuModelType, dwsuModelList)
-	if diagnostics.HasError() {
-		resp.Diagnostics.Append(diagnostics...)
-		//return
+	if dwsu.Edition != nil {
+		edition = dwsu.Edition.ID
+	}
+	if !matchFilter(config.Cloud, cloud) || !matchFilter(config.Region, region) ||
+		!matchFilter(config.Edition, edition) || !matchFilter(config.Status, dwsu.Status) {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(dwsu.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func mapDwsuDataModel(ctx context.Context, dwsuModel *client.DwsuModel, diagnostics *diag.Diagnostics) model.DwsuDataModel {
+	slice := model.DwsuDataModel{
+		PlainDwsuModel: model.PlainDwsuModel{
+			ID:     types.StringValue(dwsuModel.ID),
+			Alias:  types.StringValue(dwsuModel.Alias),
+			Domain: types.StringValue(dwsuModel.Domain),
+		},
+	}
+	if dwsuModel.Region != nil && dwsuModel.Region.Cloud != nil {
+		slice.Cloud = types.StringValue(dwsuModel.Region.Cloud.ID)
+		slice.Region = types.StringValue(dwsuModel.Region.ID)
+	}
+	if dwsuModel.Variant != nil {
+		slice.Variant = types.StringValue(dwsuModel.Variant.ID)
+	}
+	if dwsuModel.Edition != nil {
+		slice.Edition = types.StringValue(dwsuModel.Edition.ID)
+	}
+	slice.Endpoints = common.MapEndpoints(ctx, dwsuModel.Endpoints, diagnostics)
+	common.MapDwsuAudit(dwsuModel, &slice.PlainDwsuModel)
+	if dwsuModel.DefaultDps != nil {
+		defaultDps := mapDpsListItem(dwsuModel.DefaultDps)
+		slice.DefaultDps = &defaultDps
 	}
-	tfListModel := model.DwsuListModel{DwsuList: dwsuList}
-	resp.State.Set(ctx, &tfListModel)
+	return slice
 }
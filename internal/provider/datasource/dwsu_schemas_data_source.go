@@ -75,15 +75,12 @@ func (d *DwsuSchemasDataSource) Read(ctx context.Context, req datasource.ReadReq
 	//}
 
 	records, _ := common.ScrollPageRecords(&resp.Diagnostics, func(pageSize, pageNum int) ([]*client.SchemaMeta, error) {
-		listRecords, err := common.CommonRetry(ctx, func() (*client.CommonPage[client.SchemaMeta], error) {
-			schemas, err := dbClient.ListSchemas(ctx, client.SchemaPageQuery{
-				PageQuery: client.PageQuery{
-					PageSize:   pageSize,
-					PageNumber: pageNum,
-				},
-				Database: state.Database.ValueStringPointer(),
-			})
-			return schemas, err
+		listRecords, err := dbClient.ListSchemas(ctx, client.SchemaPageQuery{
+			PageQuery: client.PageQuery{
+				PageSize:   pageSize,
+				PageNumber: pageNum,
+			},
+			Database: state.Database.ValueStringPointer(),
 		})
 		if err != nil {
 			return nil, err
@@ -0,0 +1,120 @@
+package datasource
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var (
+	_ datasource.DataSource              = &DwUserListDataSource{}
+	_ datasource.DataSourceWithConfigure = &DwUserListDataSource{}
+)
+
+func NewDwUserListDataSource() datasource.DataSource {
+	return &DwUserListDataSource{}
+}
+
+// DwUserListDataSource lists the accounts of a dwsu.
+type DwUserListDataSource struct {
+	RelytClientDatasource
+}
+
+func (d *DwUserListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dwusers"
+}
+
+// Schema defines the schema for the data source.
+func (d *DwUserListDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"dwsu_id":     schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
+			"name_prefix": schema.StringAttribute{Optional: true, Description: "Only return the DW users whose name starts with this prefix."},
+			"accounts": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The DW users of the service unit.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                                  schema.StringAttribute{Computed: true, Description: "The ID of the DW user."},
+						"account_name":                        schema.StringAttribute{Computed: true, Description: "The name of the DW user."},
+						"create_timestamp":                    schema.Int64Attribute{Computed: true, Description: "The time the DW user was created, in unix milliseconds."},
+						"mfa_enabled":                         schema.BoolAttribute{Computed: true, Description: "Whether the DW user has MFA enabled."},
+						"datalake_aws_lakeformation_role_arn": schema.StringAttribute{Computed: true, Description: "The ARN of the cross-account IAM role."},
+						"async_query_result_location_prefix":  schema.StringAttribute{Computed: true, Description: "The prefix of the path to the S3 output location."},
+						"async_query_result_location_aws_role_arn": schema.StringAttribute{Computed: true, Description: "The ARN of the role to access the output location."},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DwUserListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state model.DwUserListModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.DwsuId.ValueString() == "" {
+		resp.Diagnostics.AddError("parameter error", "dwsu_id can't be empty")
+		return
+	}
+	meta := common.RouteRegionUri(ctx, state.DwsuId.ValueString(), d.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	namePrefix := state.NamePrefix.ValueString()
+	accounts, _ := common.ScrollPageRecords(&resp.Diagnostics,
+		func(pageSize, pageNum int) ([]*client.AccountInfo, error) {
+			return d.client.ListAccounts(ctx, meta.URI, state.DwsuId.ValueString(), pageSize, pageNum, namePrefix)
+		})
+	//ScrollPageRecords已经写入了错误
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Accounts = []model.DwUserListItem{}
+	for _, account := range accounts {
+		// 服务端未按前缀过滤时在此兜底
+		if account == nil || !strings.HasPrefix(account.Name, namePrefix) {
+			continue
+		}
+		state.Accounts = append(state.Accounts, mapDwUserListItem(account))
+	}
+	tflog.Info(ctx, "dwuser list size: "+strconv.Itoa(len(state.Accounts)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func mapDwUserListItem(account *client.AccountInfo) model.DwUserListItem {
+	item := model.DwUserListItem{
+		ID:                                 types.StringValue(account.Name),
+		AccountName:                        types.StringValue(account.Name),
+		CreateTimestamp:                    types.Int64Value(account.CreateTimestamp),
+		MfaEnabled:                         types.BoolValue(account.MfaEnabled),
+		DatalakeAwsLakeformationRoleArn:    types.StringNull(),
+		AsyncQueryResultLocationPrefix:     types.StringNull(),
+		AsyncQueryResultLocationAwsRoleArn: types.StringNull(),
+	}
+	if account.ID != "" {
+		item.ID = types.StringValue(account.ID)
+	}
+	if account.LakeFormation != nil && account.LakeFormation.IAMRole != "" {
+		item.DatalakeAwsLakeformationRoleArn = types.StringValue(account.LakeFormation.IAMRole)
+	}
+	if account.AsyncResult != nil {
+		if account.AsyncResult.S3LocationPrefix != "" {
+			item.AsyncQueryResultLocationPrefix = types.StringValue(account.AsyncResult.S3LocationPrefix)
+		}
+		if account.AsyncResult.AwsIamArn != "" {
+			item.AsyncQueryResultLocationAwsRoleArn = types.StringValue(account.AsyncResult.AwsIamArn)
+		}
+	}
+	return item
+}
@@ -0,0 +1,89 @@
+package ephemeral
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &Boto3EphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &Boto3EphemeralResource{}
+)
+
+func NewBoto3EphemeralResource() ephemeral.EphemeralResource {
+	return &Boto3EphemeralResource{}
+}
+
+// Boto3EphemeralResource reads the boto3 access infos of a dw user without persisting them in plan or state.
+type Boto3EphemeralResource struct {
+	client *client.RelytClient
+}
+
+func (e *Boto3EphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dwsu_boto3_access_info"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *Boto3EphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"dwsu_id":    schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
+			"account_id": schema.StringAttribute{Required: true, Description: "The ID of the account"},
+			"boto3_access_infos": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"access_key_id": schema.StringAttribute{Computed: true, Description: "The ID of the key"},
+						"access_key":    schema.StringAttribute{Computed: true, Description: "AccessKey"},
+						"secret_key":    schema.StringAttribute{Computed: true, Sensitive: true, Description: "SecretKey"},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Open reads the access infos, they only live in the result of this run.
+func (e *Boto3EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var result model.Boto3AccessInfoModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, result.DwsuId.ValueString(), e.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	boto3AccessInfo, err := e.client.GetBoto3AccessInfo(ctx, meta.URI, result.DwsuId.ValueString(), result.DwUserId.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "error read boto3 access info:"+err.Error())
+		resp.Diagnostics.AddError("read failed!", "error read boto3:"+err.Error())
+		return
+	}
+	result.Boto3AccessInfos = common.MapBoto3AccessInfos(ctx, boto3AccessInfo, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, result)...)
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *Boto3EphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+	relytClient, ok := req.ProviderData.(*client.RelytClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *RelytClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	e.client = relytClient
+}
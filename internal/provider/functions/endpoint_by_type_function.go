@@ -0,0 +1,50 @@
+package functions
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/common"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var _ function.Function = &EndpointByTypeFunction{}
+
+func NewEndpointByTypeFunction() function.Function {
+	return &EndpointByTypeFunction{}
+}
+
+// EndpointByTypeFunction picks the endpoint of a type out of the endpoints of a dwsu.
+type EndpointByTypeFunction struct{}
+
+func (f *EndpointByTypeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "endpoint_by_type"
+}
+
+func (f *EndpointByTypeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Find an endpoint by type",
+		Description: "Returns the first endpoint of the given type, null if there is none.",
+		Parameters: []function.Parameter{
+			function.ListParameter{Name: "endpoints", Description: "The endpoints of a service unit.", ElementType: common.EndpointsType},
+			function.StringParameter{Name: "type", Description: "The type of the endpoint. enum: {openapi, web_console, database}"},
+		},
+		Return: function.ObjectReturn{AttributeTypes: common.EndpointsType.AttrTypes},
+	}
+}
+
+func (f *EndpointByTypeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var endpoints []model.Endpoints
+	var endpointType string
+	resp.Error = req.Arguments.Get(ctx, &endpoints, &endpointType)
+	if resp.Error != nil {
+		return
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.Type.ValueString() == endpointType {
+			resp.Error = resp.Result.Set(ctx, endpoint)
+			return
+		}
+	}
+	resp.Error = resp.Result.Set(ctx, types.ObjectNull(common.EndpointsType.AttrTypes))
+}
@@ -0,0 +1,42 @@
+package functions
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"terraform-provider-relyt/internal/provider/common"
+)
+
+var _ function.Function = &ExternalSchemaIdFunction{}
+
+func NewExternalSchemaIdFunction() function.Function {
+	return &ExternalSchemaIdFunction{}
+}
+
+// ExternalSchemaIdFunction builds the import id of a relyt_dwsu_external_schema.
+type ExternalSchemaIdFunction struct{}
+
+func (f *ExternalSchemaIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "external_schema_id"
+}
+
+func (f *ExternalSchemaIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the import id of an external schema",
+		Description: "Returns database,catalog,name, or base64,<database>,<catalog>,<name> with each part base64 encoded when a part contains a comma.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "database", Description: "The name of the database."},
+			function.StringParameter{Name: "catalog", Description: "The name of the catalog."},
+			function.StringParameter{Name: "name", Description: "The name of the external schema."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ExternalSchemaIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var database, catalog, name string
+	resp.Error = req.Arguments.Get(ctx, &database, &catalog, &name)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, common.ExternalSchemaId(database, catalog, name))
+}
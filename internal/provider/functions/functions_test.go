@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/common"
+	"testing"
+)
+
+func endpointValue(t *testing.T, endpointType, host string, port int32) types.Object {
+	endpoint, diags := types.ObjectValue(common.EndpointsType.AttrTypes, map[string]attr.Value{
+		"extensions": types.MapNull(types.StringType),
+		"host":       types.StringValue(host),
+		"id":         types.StringValue(endpointType),
+		"open":       types.BoolValue(true),
+		"port":       types.Int32Value(port),
+		"protocol":   types.StringValue("JDBC"),
+		"type":       types.StringValue(endpointType),
+		"uri":        types.StringValue(""),
+	})
+	if diags.HasError() {
+		t.Fatalf("build endpoint: %v", diags)
+	}
+	return endpoint
+}
+
+func run(f function.Function, args ...attr.Value) *function.RunResponse {
+	definition := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &definition)
+	resp := &function.RunResponse{Result: function.NewResultData(definition.Definition.Return.GetType().ValueType(context.Background()))}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp
+}
+
+func TestJdbcUrlFunction(t *testing.T) {
+	resp := run(NewJdbcUrlFunction(), endpointValue(t, "database", "db.relyt.cn", 5432), types.StringValue("dev"))
+	if resp.Error != nil || !resp.Result.Value().Equal(types.StringValue("jdbc:postgresql://db.relyt.cn:5432/dev")) {
+		t.Errorf("unexpected jdbc url: %v %v", resp.Result.Value(), resp.Error)
+	}
+}
+
+func TestEndpointByTypeFunction(t *testing.T) {
+	endpoints, _ := types.ListValue(common.EndpointsType, []attr.Value{
+		endpointValue(t, "openapi", "api.relyt.cn", 443),
+		endpointValue(t, "database", "db.relyt.cn", 5432),
+	})
+	resp := run(NewEndpointByTypeFunction(), endpoints, types.StringValue("database"))
+	if resp.Error != nil || !resp.Result.Value().Equal(endpointValue(t, "database", "db.relyt.cn", 5432)) {
+		t.Errorf("unexpected endpoint: %v %v", resp.Result.Value(), resp.Error)
+	}
+	resp = run(NewEndpointByTypeFunction(), endpoints, types.StringValue("web_console"))
+	if resp.Error != nil || !resp.Result.Value().IsNull() {
+		t.Errorf("missing endpoint should be null: %v %v", resp.Result.Value(), resp.Error)
+	}
+}
+
+func TestParseDpsImportIdFunction(t *testing.T) {
+	resp := run(NewParseDpsImportIdFunction(), types.StringValue("dwsu1,dps1"))
+	expected, _ := types.ObjectValue(dpsImportIdAttrTypes, map[string]attr.Value{
+		"dwsu_id": types.StringValue("dwsu1"),
+		"dps_id":  types.StringValue("dps1"),
+	})
+	if resp.Error != nil || !resp.Result.Value().Equal(expected) {
+		t.Errorf("unexpected ids: %v %v", resp.Result.Value(), resp.Error)
+	}
+	for _, id := range []string{"dwsu1", "dwsu1,", "dwsu1,dps1,x"} {
+		if resp = run(NewParseDpsImportIdFunction(), types.StringValue(id)); resp.Error == nil {
+			t.Errorf("id %q should be rejected", id)
+		}
+	}
+}
+
+func TestExternalSchemaIdFunction(t *testing.T) {
+	resp := run(NewExternalSchemaIdFunction(), types.StringValue("db"), types.StringValue("catalog"), types.StringValue("schema"))
+	if resp.Error != nil || !resp.Result.Value().Equal(types.StringValue("db,catalog,schema")) {
+		t.Errorf("unexpected id: %v %v", resp.Result.Value(), resp.Error)
+	}
+	resp = run(NewExternalSchemaIdFunction(), types.StringValue("db"), types.StringValue("cat,alog"), types.StringValue("schema"))
+	if resp.Error != nil || !resp.Result.Value().Equal(types.StringValue("base64,ZGI=,Y2F0LGFsb2c=,c2NoZW1h")) {
+		t.Errorf("unexpected base64 id: %v %v", resp.Result.Value(), resp.Error)
+	}
+}
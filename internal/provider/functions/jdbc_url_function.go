@@ -0,0 +1,45 @@
+package functions
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	"terraform-provider-relyt/internal/provider/model"
+)
+
+var _ function.Function = &JdbcUrlFunction{}
+
+func NewJdbcUrlFunction() function.Function {
+	return &JdbcUrlFunction{}
+}
+
+// JdbcUrlFunction builds the jdbc url of a database on a dwsu endpoint.
+type JdbcUrlFunction struct{}
+
+func (f *JdbcUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jdbc_url"
+}
+
+func (f *JdbcUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the JDBC url of a database",
+		Description: "Returns jdbc:postgresql://host:port/database of the given endpoint, usually the database endpoint of a relyt_dwsu.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{Name: "endpoint", Description: "An endpoint of a service unit.", AttributeTypes: common.EndpointsType.AttrTypes},
+			function.StringParameter{Name: "database", Description: "The name of the database, an empty string leaves it out of the url."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JdbcUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var endpoint model.Endpoints
+	var database string
+	resp.Error = req.Arguments.Get(ctx, &endpoint, &database)
+	if resp.Error != nil {
+		return
+	}
+	url := common.JdbcUrl(&client.Endpoints{Host: endpoint.Host.ValueString(), Port: endpoint.Port.ValueInt32()}, database)
+	resp.Error = resp.Result.Set(ctx, url)
+}
@@ -0,0 +1,60 @@
+package functions
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/common"
+)
+
+var _ function.Function = &ParseDpsImportIdFunction{}
+
+var dpsImportIdAttrTypes = map[string]attr.Type{
+	"dwsu_id": types.StringType,
+	"dps_id":  types.StringType,
+}
+
+func NewParseDpsImportIdFunction() function.Function {
+	return &ParseDpsImportIdFunction{}
+}
+
+// ParseDpsImportIdFunction splits the import id of a relyt_dps.
+type ParseDpsImportIdFunction struct{}
+
+func (f *ParseDpsImportIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_dps_import_id"
+}
+
+func (f *ParseDpsImportIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse the import id of a DPS cluster",
+		Description: "Splits dwsu_id,dps_id into an object with dwsu_id and dps_id.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "id", Description: "The import id of relyt_dps, dwsu_id,dps_id."},
+		},
+		Return: function.ObjectReturn{AttributeTypes: dpsImportIdAttrTypes},
+	}
+}
+
+func (f *ParseDpsImportIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+	dwsuId, dpsId, err := common.ParseDpsImportId(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	result, diags := types.ObjectValue(dpsImportIdAttrTypes, map[string]attr.Value{
+		"dwsu_id": types.StringValue(dwsuId),
+		"dps_id":  types.StringValue(dpsId),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -12,6 +13,11 @@ type RelytProviderModel struct {
 	ResourceCheckTimeout  types.Int64       `tfsdk:"resource_check_timeout"`
 	ResourceCheckInterval types.Int64       `tfsdk:"resource_check_interval"`
 	ClientTimeout         types.Int64       `tfsdk:"client_timeout"`
+	MaxRetries            types.Int64       `tfsdk:"max_retries"`
+	RetryMaxBackoff       types.Int64       `tfsdk:"retry_max_backoff"`
+	DeletionProtection    types.Bool        `tfsdk:"deletion_protection"`
+	LogRedactHeaders      types.List        `tfsdk:"log_redact_headers"`
+	LogRedactFields       types.List        `tfsdk:"log_redact_fields"`
 	DataAccessConfig      *DataAccessConfig `tfsdk:"data_access_config"`
 }
 type Endpoints struct {
@@ -26,11 +32,12 @@ type Endpoints struct {
 }
 
 type Dps struct {
-	Description types.String `tfsdk:"description"`
-	Engine      types.String `tfsdk:"engine"`
-	Name        types.String `tfsdk:"name"`
-	Size        types.String `tfsdk:"size"`
-	Status      types.String `tfsdk:"status"`
+	Description  types.String `tfsdk:"description"`
+	Engine       types.String `tfsdk:"engine"`
+	Name         types.String `tfsdk:"name"`
+	Size         types.String `tfsdk:"size"`
+	Status       types.String `tfsdk:"status"`
+	DesiredState types.String `tfsdk:"desired_state"`
 }
 
 type PlainDwsuModel struct {
@@ -44,17 +51,49 @@ type PlainDwsuModel struct {
 	//Endpoints  []Endpoints  `tfsdk:"endpoints"`
 	Endpoints types.List `tfsdk:"endpoints"`
 	//LastUpdated types.Int64  `tfsdk:"last_updated"`
-	//Status      types.String `tfsdk:"status"`
+	Status          types.String `tfsdk:"status"`
+	Creator         types.String `tfsdk:"creator"`
+	Owner           types.String `tfsdk:"owner"`
+	CreateTimestamp types.Int64  `tfsdk:"create_timestamp"`
+	UpdateTimestamp types.Int64  `tfsdk:"update_timestamp"`
+}
+
+// DwsuDataModel is a dwsu read by relyt_dwsu and relyt_dwsus.
+type DwsuDataModel struct {
+	PlainDwsuModel
+	DefaultDps *DpsListItem `tfsdk:"default_dps"`
 }
 
 type DwsuModel struct {
 	PlainDwsuModel
-	DefaultDps *Dps `tfsdk:"default_dps"`
+	Tags       types.Set      `tfsdk:"tags"`
+	DefaultDps *Dps           `tfsdk:"default_dps"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
+	DatabaseEndpoint     types.Object `tfsdk:"database_endpoint"`
+	OpenapiEndpoint      types.Object `tfsdk:"openapi_endpoint"`
+	WebConsoleEndpoint   types.Object `tfsdk:"web_console_endpoint"`
+	JdbcUrl              types.String `tfsdk:"jdbc_url"`
+	PsqlConnectionString types.String `tfsdk:"psql_connection_string"`
+}
+
+// DpsLifecycle holds the idle and scaling settings of a standalone dps.
+type DpsLifecycle struct {
+	AutoSuspend          types.Bool   `tfsdk:"auto_suspend"`
+	AutoResume           types.Bool   `tfsdk:"auto_resume"`
+	KeepAliveTime        types.Int64  `tfsdk:"keep_alive_time"`
+	AdaptiveQueryScaling types.Bool   `tfsdk:"adaptive_query_scaling"`
+	AqsSize              types.String `tfsdk:"aqs_size"`
 }
 
 type DpsModel struct {
-	DwsuId types.String `tfsdk:"dwsu_id"`
-	ID     types.String `tfsdk:"id"`
+	DwsuId             types.String   `tfsdk:"dwsu_id"`
+	ID                 types.String   `tfsdk:"id"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	DpsLifecycle
 	Dps
 	//Name        types.String `tfsdk:"name"`
 	//Description types.String `tfsdk:"description"`
@@ -65,19 +104,37 @@ type DpsModel struct {
 }
 
 type DWUserModel struct {
-	DwsuId                             types.String `tfsdk:"dwsu_id"`
-	ID                                 types.String `tfsdk:"id"`
-	AccountName                        types.String `tfsdk:"account_name"`
-	AccountPassword                    types.String `tfsdk:"account_password"`
-	DatalakeAwsLakeformationRoleArn    types.String `tfsdk:"datalake_aws_lakeformation_role_arn"`
-	AsyncQueryResultLocationPrefix     types.String `tfsdk:"async_query_result_location_prefix"`
-	AsyncQueryResultLocationAwsRoleArn types.String `tfsdk:"async_query_result_location_aws_role_arn"`
+	DwsuId                             types.String       `tfsdk:"dwsu_id"`
+	ID                                 types.String       `tfsdk:"id"`
+	AccountName                        types.String       `tfsdk:"account_name"`
+	AccountPassword                    types.String       `tfsdk:"account_password"`
+	AccountPasswordWo                  types.String       `tfsdk:"account_password_wo"`
+	AccountPasswordWoVersion           types.Int64        `tfsdk:"account_password_wo_version"`
+	PasswordGenerator                  *PasswordGenerator `tfsdk:"password_generator"`
+	GeneratedPassword                  types.String       `tfsdk:"generated_password"`
+	PasswordRotatedAt                  types.String       `tfsdk:"password_rotated_at"`
+	DatalakeAwsLakeformationRoleArn    types.String       `tfsdk:"datalake_aws_lakeformation_role_arn"`
+	AsyncQueryResultLocationPrefix     types.String       `tfsdk:"async_query_result_location_prefix"`
+	AsyncQueryResultLocationAwsRoleArn types.String       `tfsdk:"async_query_result_location_aws_role_arn"`
 	//LastUpdated                        types.String `tfsdk:"last_updated"`
 	//Status                             types.String `tfsdk:"status"`
 }
 
+// PasswordGenerator configures the password relyt_dwuser generates and rotates.
+type PasswordGenerator struct {
+	Length            types.Int64  `tfsdk:"length"`
+	SpecialCharacters types.String `tfsdk:"special_characters"`
+	RotateAfter       types.String `tfsdk:"rotate_after"`
+	Keepers           types.Map    `tfsdk:"keepers"`
+}
+
 type DwsuListModel struct {
-	DwsuList types.List `tfsdk:"dwsu_list"`
+	Cloud    types.String    `tfsdk:"cloud"`
+	Region   types.String    `tfsdk:"region"`
+	Edition  types.String    `tfsdk:"edition"`
+	Status   types.String    `tfsdk:"status"`
+	Tags     types.Set       `tfsdk:"tags"`
+	DwsuList []DwsuDataModel `tfsdk:"dwsu_list"`
 }
 
 type CloudRegionEndpoints struct {
@@ -85,3 +142,44 @@ type CloudRegionEndpoints struct {
 	Region    types.String `tfsdk:"region"`
 	Endpoints types.List   `tfsdk:"endpoints"`
 }
+
+type DpsSpecs struct {
+	Edition types.String `tfsdk:"edition"`
+	Engine  types.String `tfsdk:"engine"`
+	Cloud   types.String `tfsdk:"cloud"`
+	Region  types.String `tfsdk:"region"`
+	Names   []string     `tfsdk:"names"`
+	Specs   []DpsSpec    `tfsdk:"specs"`
+}
+
+type DpsSpec struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	UsageRates []UsageRate  `tfsdk:"usage_rates"`
+}
+
+type UsageRate struct {
+	Amount types.Float64 `tfsdk:"amount"`
+	Type   types.String  `tfsdk:"type"`
+}
+
+type DpsList struct {
+	DwsuId  types.String  `tfsdk:"dwsu_id"`
+	Name    types.String  `tfsdk:"name"`
+	Engine  types.String  `tfsdk:"engine"`
+	Status  types.String  `tfsdk:"status"`
+	DpsList []DpsListItem `tfsdk:"dps_list"`
+}
+
+type DpsListItem struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Engine      types.String `tfsdk:"engine"`
+	Size        types.String `tfsdk:"size"`
+	Status      types.String `tfsdk:"status"`
+	DpsLifecycle
+	Creator    types.String `tfsdk:"creator"`
+	CreateTime types.Int64  `tfsdk:"create_time"`
+	UpdateTime types.Int64  `tfsdk:"update_time"`
+}
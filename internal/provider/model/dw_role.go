@@ -0,0 +1,28 @@
+package model
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type DwRoleModel struct {
+	ID              types.String `tfsdk:"id"`
+	DwsuId          types.String `tfsdk:"dwsu_id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	CreateTimestamp types.Int64  `tfsdk:"create_timestamp"`
+}
+
+type DwRoleGrantModel struct {
+	ID          types.String `tfsdk:"id"`
+	DwsuId      types.String `tfsdk:"dwsu_id"`
+	Role        types.String `tfsdk:"role"`
+	AccountName types.String `tfsdk:"account_name"`
+}
+
+type DwPrivilegeModel struct {
+	ID         types.String `tfsdk:"id"`
+	Grantee    types.String `tfsdk:"grantee"`
+	ObjectType types.String `tfsdk:"object_type"`
+	Database   types.String `tfsdk:"database"`
+	Schema     types.String `tfsdk:"schema"`
+	Table      types.String `tfsdk:"table"`
+	Privileges types.Set    `tfsdk:"privileges"`
+}
@@ -40,6 +40,11 @@ type DwsuDatabaseMeta struct {
 	Owner types.String `tfsdk:"owner"`
 }
 
+type DwsuDatabaseModel struct {
+	DwsuDatabaseMeta
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+}
+
 type DwsuSchemas struct {
 	Database types.String `tfsdk:"database"`
 	Schemas  types.List   `tfsdk:"schemas"`
@@ -31,3 +31,19 @@ type DwsuUserSecurityPolicy struct {
 	ResetInitPassword types.Bool   `tfsdk:"reset_init_password"`
 	//MFAProtectionScopes types.Set    `tfsdk:"mfa_protection_scopes"`
 }
+
+type DwUserListModel struct {
+	DwsuId     types.String     `tfsdk:"dwsu_id"`
+	NamePrefix types.String     `tfsdk:"name_prefix"`
+	Accounts   []DwUserListItem `tfsdk:"accounts"`
+}
+
+type DwUserListItem struct {
+	ID                                 types.String `tfsdk:"id"`
+	AccountName                        types.String `tfsdk:"account_name"`
+	CreateTimestamp                    types.Int64  `tfsdk:"create_timestamp"`
+	MfaEnabled                         types.Bool   `tfsdk:"mfa_enabled"`
+	DatalakeAwsLakeformationRoleArn    types.String `tfsdk:"datalake_aws_lakeformation_role_arn"`
+	AsyncQueryResultLocationPrefix     types.String `tfsdk:"async_query_result_location_prefix"`
+	AsyncQueryResultLocationAwsRoleArn types.String `tfsdk:"async_query_result_location_aws_role_arn"`
+}
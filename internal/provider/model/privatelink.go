@@ -1,13 +1,17 @@
 package model
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type PrivateLinkModel struct {
-	DwsuId          types.String `tfsdk:"dwsu_id"`
-	ServiceType     types.String `tfsdk:"service_type"`
-	ServiceName     types.String `tfsdk:"service_name"`
-	Status          types.String `tfsdk:"status"`
-	AllowPrincipals types.List   `tfsdk:"allow_principals"`
+	DwsuId          types.String   `tfsdk:"dwsu_id"`
+	ServiceType     types.String   `tfsdk:"service_type"`
+	ServiceName     types.String   `tfsdk:"service_name"`
+	Status          types.String   `tfsdk:"status"`
+	AllowPrincipals types.List     `tfsdk:"allow_principals"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 type AllowPrinciple struct {
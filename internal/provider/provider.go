@@ -7,17 +7,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"math"
 	"os"
 	"strconv"
 	"terraform-provider-relyt/internal/provider/client"
 	relytDS "terraform-provider-relyt/internal/provider/datasource"
+	relytER "terraform-provider-relyt/internal/provider/ephemeral"
+	relytFN "terraform-provider-relyt/internal/provider/functions"
 	"terraform-provider-relyt/internal/provider/model"
 	relytRS "terraform-provider-relyt/internal/provider/resource"
 )
@@ -25,6 +29,7 @@ import (
 // Ensure RelytProvider satisfies various provider interfaces.
 var _ provider.Provider = &RelytProvider{}
 var _ provider.ProviderWithFunctions = &RelytProvider{}
+var _ provider.ProviderWithEphemeralResources = &RelytProvider{}
 var _ provider.ProviderWithMetaSchema = &RelytProvider{}
 
 // RelytProvider defines the provider implementation.
@@ -106,7 +111,7 @@ func (p *RelytProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			},
 			"resource_check_timeout": schema.Int64Attribute{
 				Optional:    true,
-				Description: "Timeout second used in wait for create and delete dwsu or dps! Defaults 1800",
+				Description: "Timeout second used in wait for create, update and delete of dwsu, dps or privatelink, unless set in the timeouts block of the resource! Defaults 1800",
 			},
 			"resource_check_interval": schema.Int64Attribute{
 				Optional:    true,
@@ -116,6 +121,28 @@ func (p *RelytProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "http client timeout seconds! Defaults 10",
 			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Max retries of an idempotent api call failed by network error, 429 or 5xx! Set 0 to disable. Defaults 3",
+			},
+			"retry_max_backoff": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Max wait seconds between two retries, Retry-After included! Defaults 30",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Default deletion_protection of relyt_dwsu, relyt_dps and relyt_dwsu_database when not set in the resource! Defaults true",
+			},
+			"log_redact_headers": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Extra http header names masked in TF_LOG output, in addition to the api key, role and signature headers.",
+			},
+			"log_redact_fields": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Extra json body field names masked in TF_LOG output, in addition to passwords and access keys. Bodies are only logged at TRACE level.",
+			},
 			"data_access_config": schema.SingleNestedAttribute{
 				Optional:    true,
 				Description: "data_access_configs",
@@ -206,18 +233,47 @@ func (p *RelytProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if !data.ResourceCheckInterval.IsNull() {
 		tflog.Info(ctx, "resource check wait isn't null! set value:"+strconv.FormatInt(data.ResourceCheckTimeout.ValueInt64(), 10))
 		if data.ResourceCheckInterval.ValueInt64() < 5 || data.ResourceCheckInterval.ValueInt64() >= math.MaxInt32 {
-			resp.Diagnostics.AddAttributeError(path.Root("resource_check_interval"), "invalid value", "should be grater than 5")
+			resp.Diagnostics.AddAttributeError(path.Root("resource_check_interval"), "invalid value", "should be greater than 5")
 		}
 		checkInterval = int32(data.ResourceCheckInterval.ValueInt64())
 	}
 	if !data.ClientTimeout.IsNull() {
 		tflog.Info(ctx, "client timeout isn't null! set value:"+strconv.FormatInt(data.ClientTimeout.ValueInt64(), 10))
 		if data.ClientTimeout.ValueInt64() <= 1 || data.ClientTimeout.ValueInt64() >= math.MaxInt32 {
-			resp.Diagnostics.AddAttributeError(path.Root("client_timeout"), "invalid value", "should be grater than 1")
+			resp.Diagnostics.AddAttributeError(path.Root("client_timeout"), "invalid value", "should be greater than 1")
 		}
 		clientTimeout = int32(data.ClientTimeout.ValueInt64())
 	}
+	retryConfig := client.RetryConfig{MaxRetries: 3, RetryMaxBackoff: 30}
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 || data.MaxRetries.ValueInt64() > 100 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "invalid value", "should be between 0 and 100")
+		}
+		retryConfig.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.RetryMaxBackoff.IsNull() {
+		if data.RetryMaxBackoff.ValueInt64() < 1 || data.RetryMaxBackoff.ValueInt64() >= math.MaxInt32 {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_max_backoff"), "invalid value", "should be greater than 0")
+		}
+		retryConfig.RetryMaxBackoff = int32(data.RetryMaxBackoff.ValueInt64())
+	}
+
+	deletionProtection := true
+	if !data.DeletionProtection.IsNull() {
+		deletionProtection = data.DeletionProtection.ValueBool()
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	redactConfig := client.LogRedactConfig{}
+	if !data.LogRedactHeaders.IsNull() {
+		resp.Diagnostics.Append(data.LogRedactHeaders.ElementsAs(ctx, &redactConfig.RedactHeaders, false)...)
+	}
+	if !data.LogRedactFields.IsNull() {
+		resp.Diagnostics.Append(data.LogRedactFields.ElementsAs(ctx, &redactConfig.RedactFields, false)...)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -225,23 +281,28 @@ func (p *RelytProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	// Example client configuration for data sources and resources
 
 	// Create a new Relyt client using the configuration values
-	tflog.Info(ctx, fmt.Sprintf(" host: %s auth: %s, role: %s  check timeout: %d interval: %d",
-		apiHost, authKey, data.Role.ValueString(), resourceWaitTimeout, checkInterval))
+	tflog.Info(ctx, fmt.Sprintf(" host: %s check timeout: %d interval: %d",
+		apiHost, resourceWaitTimeout, checkInterval))
 	roleId := data.Role.ValueString()
 	clientConfig := client.RelytClientConfig{
-		ApiHost:       apiHost,
-		AuthKey:       authKey,
-		Role:          roleId,
-		CheckTimeOut:  resourceWaitTimeout,
-		CheckInterval: checkInterval,
-		ClientTimeout: clientTimeout,
+		ApiHost:            apiHost,
+		AuthKey:            authKey,
+		Role:               roleId,
+		CheckTimeOut:       resourceWaitTimeout,
+		CheckInterval:      checkInterval,
+		ClientTimeout:      clientTimeout,
+		DeletionProtection: deletionProtection,
+		LogRedactConfig:    redactConfig,
+		RetryConfig:        retryConfig,
 	}
 	if data.DataAccessConfig != nil {
 		clientConfig.RelytDatabaseClientConfig = &client.RelytDatabaseClientConfig{
-			DmsHost:       data.DataAccessConfig.Endpoint.ValueString(),
-			AccessKey:     data.DataAccessConfig.AccessKey.ValueString(),
-			SecretKey:     data.DataAccessConfig.SecretKey.ValueString(),
-			ClientTimeout: 60,
+			DmsHost:         data.DataAccessConfig.Endpoint.ValueString(),
+			AccessKey:       data.DataAccessConfig.AccessKey.ValueString(),
+			SecretKey:       data.DataAccessConfig.SecretKey.ValueString(),
+			ClientTimeout:   60,
+			LogRedactConfig: redactConfig,
+			RetryConfig:     retryConfig,
 		}
 		//if clientConfig.RelytDatabaseClientConfig.AccessKey == "" {
 		//	resp.Diagnostics.AddError("data_access_config error", "access_key can't be empty string")
@@ -278,6 +339,7 @@ func (p *RelytProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 	resp.DataSourceData = &relytClient
 	resp.ResourceData = &relytClient
+	resp.EphemeralResourceData = &relytClient
 }
 
 func (p *RelytProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -290,6 +352,9 @@ func (p *RelytProvider) Resources(ctx context.Context) []func() resource.Resourc
 		relytRS.NewDwsuDatabaseResource,
 		relytRS.NewDwsuExternalSchemaResource,
 		relytRS.NewdwsuUserPolicy,
+		relytRS.NewDwRoleResource,
+		relytRS.NewDwRoleGrantResource,
+		relytRS.NewDwPrivilegeResource,
 		//relytRS.NewTestResource,
 	}
 }
@@ -304,13 +369,26 @@ func (p *RelytProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		relytDS.NewDwsuSchemasDataSource,
 		relytDS.NewDwsuSchemaDetailDataSource,
 		relytDS.NewDwsuListDataSource,
+		relytDS.NewDwsuDataSource,
 		relytDS.NewCloudRegionListDataSource,
+		relytDS.NewDpsSpecsDataSource,
+		relytDS.NewDpsListDataSource,
+		relytDS.NewDwUserListDataSource,
+	}
+}
+
+func (p *RelytProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		relytER.NewBoto3EphemeralResource,
 	}
 }
 
 func (p *RelytProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		//NewExampleFunction,
+		relytFN.NewJdbcUrlFunction,
+		relytFN.NewParseDpsImportIdFunction,
+		relytFN.NewEndpointByTypeFunction,
+		relytFN.NewExternalSchemaIdFunction,
 	}
 }
 
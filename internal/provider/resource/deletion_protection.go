@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/client"
+)
+
+const deletionProtectionAttr = "deletion_protection"
+
+// deletionProtectionAttribute has no static default, planDeletionProtection fills the provider default instead.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Description: "Whether terraform is prevented from deleting the " + kind + ". Set false and apply before destroying or replacing it. " +
+			"Defaults to the provider deletion_protection, which is true unless set.",
+	}
+}
+
+// planDeletionProtection sets deletion_protection to the provider default when it's not set in config.
+func planDeletionProtection(ctx context.Context, relytClient *client.RelytClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || relytClient == nil {
+		return
+	}
+	var config types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(deletionProtectionAttr), &config)...)
+	if resp.Diagnostics.HasError() || !config.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(deletionProtectionAttr), relytClient.DeletionProtection)...)
+}
+
+// readDeletionProtection keeps the value in state, imported or upgraded states get the provider default.
+func readDeletionProtection(relytClient *client.RelytClient, state types.Bool) types.Bool {
+	if state.IsNull() || state.IsUnknown() {
+		return types.BoolValue(relytClient.DeletionProtection)
+	}
+	return state
+}
+
+// checkDeletionProtection adds an error and returns false while deletion protection of the resource is on.
+func checkDeletionProtection(relytClient *client.RelytClient, state types.Bool, typeName, id string, diagnostics *diag.Diagnostics) bool {
+	if !readDeletionProtection(relytClient, state).ValueBool() {
+		return true
+	}
+	diagnostics.AddAttributeError(path.Root(deletionProtectionAttr), "Deletion protection is enabled",
+		fmt.Sprintf("Can't delete %s %s while deletion_protection is true! Set deletion_protection = false and apply it first.", typeName, id))
+	return false
+}
@@ -0,0 +1,29 @@
+package resource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/client"
+	"testing"
+)
+
+func TestDeletionProtection_check(t *testing.T) {
+	relytClient := &client.RelytClient{RelytClientConfig: client.RelytClientConfig{DeletionProtection: true}}
+	diagnostics := diag.Diagnostics{}
+	if checkDeletionProtection(relytClient, types.BoolValue(true), "dwsu", "test", &diagnostics) || !diagnostics.HasError() {
+		t.Errorf("protected resource shouldn't be deleted")
+	}
+	diagnostics = diag.Diagnostics{}
+	if !checkDeletionProtection(relytClient, types.BoolValue(false), "dwsu", "test", &diagnostics) || diagnostics.HasError() {
+		t.Errorf("unprotected resource should be deleted, got %v", diagnostics)
+	}
+	// states written before deletion_protection existed follow the provider default
+	if checkDeletionProtection(relytClient, types.BoolNull(), "dwsu", "test", &diagnostics) {
+		t.Errorf("null deletion_protection should use provider default")
+	}
+	relytClient.DeletionProtection = false
+	if !readDeletionProtection(relytClient, types.BoolNull()).Equal(types.BoolValue(false)) ||
+		!readDeletionProtection(relytClient, types.BoolValue(true)).Equal(types.BoolValue(true)) {
+		t.Errorf("read should keep state and default null to provider value")
+	}
+}
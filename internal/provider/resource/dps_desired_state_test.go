@@ -0,0 +1,30 @@
+package resource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/model"
+	"testing"
+)
+
+func TestMapRelytDpsToTFModel_desiredState(t *testing.T) {
+	dps := model.Dps{DesiredState: types.StringValue(dpsDesiredStateSuspended)}
+	mapRelytDpsToTFModel(&client.DpsMode{Status: client.DPS_STATUS_READY}, &dps)
+	if dps.DesiredState.ValueString() != dpsDesiredStateRunning {
+		t.Errorf("desired_state should follow a dps resumed out of terraform, got %v", dps.DesiredState)
+	}
+	mapRelytDpsToTFModel(&client.DpsMode{Status: "RESUMING"}, &dps)
+	if dps.DesiredState.ValueString() != dpsDesiredStateRunning {
+		t.Errorf("desired_state should be kept while the dps is changing, got %v", dps.DesiredState)
+	}
+	autoSuspend := true
+	mapRelytDpsToTFModel(&client.DpsMode{Status: client.DPS_STATUS_SUSPENDED, EnableAutoSuspend: &autoSuspend}, &dps)
+	if dps.DesiredState.ValueString() != dpsDesiredStateRunning {
+		t.Errorf("desired_state shouldn't follow a dps suspended by auto_suspend, got %v", dps.DesiredState)
+	}
+	unset := model.Dps{DesiredState: types.StringNull()}
+	mapRelytDpsToTFModel(&client.DpsMode{Status: client.DPS_STATUS_SUSPENDED}, &unset)
+	if !unset.DesiredState.IsNull() {
+		t.Errorf("unset desired_state should stay null, got %v", unset.DesiredState)
+	}
+}
@@ -2,15 +2,19 @@ package resource
 
 import (
 	"context"
-	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"strings"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
 	"terraform-provider-relyt/internal/provider/model"
@@ -18,9 +22,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &dpsResource{}
-	_ resource.ResourceWithConfigure   = &dpsResource{}
-	_ resource.ResourceWithImportState = &dpsResource{}
+	_ resource.Resource                   = &dpsResource{}
+	_ resource.ResourceWithConfigure      = &dpsResource{}
+	_ resource.ResourceWithImportState    = &dpsResource{}
+	_ resource.ResourceWithValidateConfig = &dpsResource{}
+	_ resource.ResourceWithModifyPlan     = &dpsResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -40,20 +46,37 @@ func (r *dpsResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *dpsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *dpsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"dwsu_id":     schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
-			"name":        schema.StringAttribute{Required: true, Description: "The name of the DPS cluster."},
-			"id":          schema.StringAttribute{Computed: true, Description: "The ID of the DPS cluster.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"description": schema.StringAttribute{Optional: true, Description: "The description of the DPS cluster."},
-			"engine":      schema.StringAttribute{Required: true, Description: "The type of the DPS cluster. enum:{extreme}"},
-			"size":        schema.StringAttribute{Required: true, Description: "The name of the DPS cluster specification."},
-			"status":      schema.StringAttribute{Computed: true, Description: "The status of the DPS cluster."},
+			"dwsu_id":       schema.StringAttribute{Required: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"name":          schema.StringAttribute{Required: true, Description: "The name of the DPS cluster.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"id":            schema.StringAttribute{Computed: true, Description: "The ID of the DPS cluster.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"description":   schema.StringAttribute{Optional: true, Description: "The description of the DPS cluster."},
+			"engine":        schema.StringAttribute{Required: true, Description: "The type of the DPS cluster. hybrid, extreme, vector", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}, Validators: []validator.String{stringvalidator.OneOf(dpsEngines...)}},
+			"size":          schema.StringAttribute{Required: true, Description: "The name of the DPS cluster specification."},
+			"status":        schema.StringAttribute{Computed: true, Description: "The status of the DPS cluster."},
+			"desired_state": schema.StringAttribute{Optional: true, Description: "The state the DPS cluster is kept in. enum: {running, suspended}. Changing it resumes or suspends the cluster, unset leaves the cluster as it is. While auto_suspend is on, suspends and resumes done by the service are not reported as drift.", Validators: []validator.String{stringvalidator.OneOf(dpsDesiredStateRunning, dpsDesiredStateSuspended)}},
+			"auto_suspend": schema.BoolAttribute{Optional: true, Computed: true, Description: "Whether the DPS cluster is suspended automatically after being idle for keep_alive_time.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}},
+			"auto_resume": schema.BoolAttribute{Optional: true, Computed: true, Description: "Whether a suspended DPS cluster is resumed automatically by incoming queries.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}},
+			"keep_alive_time": schema.Int64Attribute{Optional: true, Computed: true, Description: "The idle time before the DPS cluster is suspended automatically. Only used when auto_suspend is true.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+				Validators:    []validator.Int64{int64validator.AtLeast(1)}},
+			"adaptive_query_scaling": schema.BoolAttribute{Optional: true, Computed: true, Description: "Whether adaptive query scaling is enabled for the DPS cluster.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}},
+			"aqs_size": schema.StringAttribute{Optional: true, Computed: true, Description: "The name of the specification adaptive query scaling scales out with. Only used when adaptive_query_scaling is true.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Validators:    []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"deletion_protection": deletionProtectionAttribute("DPS cluster"),
 			//"last_updated": schema.StringAttribute{Computed: true},
 			//"status":       schema.StringAttribute{Computed: true},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
 	}
 }
 
@@ -66,6 +89,11 @@ func (r *dpsResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	createTimeout, diags := dpsModel.Timeouts.Create(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	meta := common.RouteRegionUri(ctx, dpsModel.DwsuId.ValueString(), r.client, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -79,6 +107,8 @@ func (r *dpsResource) Create(ctx context.Context, req resource.CreateRequest, re
 			Name: dpsModel.Size.ValueString(),
 		},
 	}
+	fillDpsLifecycle(&dpsModel.DpsLifecycle, nil, &relytDps)
+	dpsModel.DeletionProtection = readDeletionProtection(r.client, dpsModel.DeletionProtection)
 	if dpsModel.ID.IsUnknown() {
 		// Create new dps
 		createResult, err := r.client.CreateDps(ctx, regionUri, dpsModel.DwsuId.ValueString(), relytDps)
@@ -104,11 +134,15 @@ func (r *dpsResource) Create(ctx context.Context, req resource.CreateRequest, re
 			return
 		}
 	}
-	dps, _ := WaitDpsReady(ctx, r.client, regionUri, dpsModel.DwsuId.ValueString(), dpsModel.ID.ValueString(), &resp.Diagnostics)
+	dps, _ := WaitDpsReady(ctx, r.client, regionUri, dpsModel.DwsuId.ValueString(), dpsModel.ID.ValueString(), createTimeout, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	dpsModel.Status = types.StringValue(dps.Status)
+	common.MapDpsLifecycle(dps, &dpsModel.DpsLifecycle)
+	desired := dpsModel.Dps
+	dpsModel.DesiredState = types.StringNull()
+	applyDpsDesiredState(ctx, r.client, &dpsModel.Dps, &desired, &resp.Diagnostics, dpsModel.DwsuId.ValueString(), dpsModel.ID.ValueString(), createTimeout)
 	diags = resp.State.Set(ctx, dpsModel)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -126,12 +160,32 @@ func (r *dpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	readTimeout, diags := state.Timeouts.Read(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 	dwsuId := state.DwsuId.ValueString()
 	dpsId := state.ID.ValueString()
-	readDps(ctx, dwsuId, dpsId, r.client, &resp.Diagnostics, &state.Dps)
+	meta := common.RouteRegionUri(ctx, dwsuId, r.client, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	dps, err := r.client.GetDps(ctx, meta.URI, dwsuId, dpsId)
+	if client.IsNotFound(err) || (err == nil && (dps == nil || dps.Status == client.DPS_STATUS_DROPPED)) {
+		tflog.Warn(ctx, "dps not found! remove from state: "+dpsId)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("error read", "error read dps!"+err.Error())
+		return
+	}
+	mapRelytDpsToTFModel(dps, &state.Dps)
+	common.MapDpsLifecycle(dps, &state.DpsLifecycle)
+	state.DeletionProtection = readDeletionProtection(r.client, state.DeletionProtection)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -145,7 +199,26 @@ func (r *dpsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	req.Plan.Get(ctx, &plan)
 	var state = model.DpsModel{}
 	req.State.Get(ctx, &state)
-	updateDps(ctx, r.client, &state.Dps, &plan.Dps, &resp.Diagnostics, state.DwsuId.ValueString(), state.ID.ValueString())
+	updateTimeout, diags := plan.Timeouts.Update(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	state.DeletionProtection = plan.DeletionProtection
+	// 只有变配才等待READY，暂停中的dps改描述、生命周期或恢复时不能卡在这里
+	if !plan.Size.Equal(state.Size) {
+		updateDps(ctx, r.client, &state.Dps, &plan.Dps, &resp.Diagnostics, state.DwsuId.ValueString(), state.ID.ValueString(), updateTimeout)
+	}
+	if !resp.Diagnostics.HasError() {
+		updateDpsDescription(ctx, r.client, &state.Dps, &plan.Dps, &resp.Diagnostics, state.DwsuId.ValueString(), state.ID.ValueString())
+	}
+	if !resp.Diagnostics.HasError() {
+		updateDpsLifecycle(ctx, r.client, &state.DpsLifecycle, &plan.DpsLifecycle, &resp.Diagnostics, state.DwsuId.ValueString(), state.ID.ValueString())
+	}
+	if !resp.Diagnostics.HasError() {
+		applyDpsDesiredState(ctx, r.client, &state.Dps, &plan.Dps, &resp.Diagnostics, state.DwsuId.ValueString(), state.ID.ValueString(), updateTimeout)
+	}
 	//if resp.Diagnostics.HasError() {
 	//	return
 	//}
@@ -154,6 +227,55 @@ func (r *dpsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	return
 }
 
+// ValidateConfig rejects lifecycle settings that don't take effect with the switch they depend on turned off.
+func (r *dpsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config model.DpsModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.KeepAliveTime.IsNull() && !config.AutoSuspend.IsNull() && !config.AutoSuspend.IsUnknown() && !config.AutoSuspend.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("keep_alive_time"), "invalid value", "keep_alive_time only takes effect when auto_suspend is true")
+	}
+	if !config.AqsSize.IsNull() && !config.AdaptiveQueryScaling.IsNull() && !config.AdaptiveQueryScaling.IsUnknown() && !config.AdaptiveQueryScaling.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("aqs_size"), "invalid value", "aqs_size only takes effect when adaptive_query_scaling is true")
+	}
+}
+
+// ModifyPlan fills the default deletion_protection, validates the size of a new or resized dps against the specs
+// of the dwsu's edition and region, and warns the cost change of a resize.
+func (r *dpsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, r.client, req, resp)
+	if req.Plan.Raw.IsNull() || r.client == nil || resp.Diagnostics.HasError() {
+		return
+	}
+	var plan model.DpsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.DwsuId.IsUnknown() {
+		return
+	}
+	var state *model.DpsModel
+	if !req.State.Raw.IsNull() {
+		state = &model.DpsModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() || (plan.Size.Equal(state.Size) && plan.Engine.Equal(state.Engine)) {
+			return
+		}
+	}
+	dwsu, err := r.client.GetDwsu(ctx, plan.DwsuId.ValueString())
+	if err != nil || dwsu == nil || dwsu.Edition == nil || dwsu.Region == nil || dwsu.Region.Cloud == nil {
+		// dwsu不存在时交给apply报错
+		tflog.Warn(ctx, "skip size validation, can't get edition and region of dwsu: "+plan.DwsuId.ValueString())
+		return
+	}
+	specs := validateDpsSize(ctx, r.client, dwsu.Edition.ID, dwsu.Region.Cloud.ID, dwsu.Region.ID, &plan.Dps, path.Root("size"), &resp.Diagnostics)
+	// 引擎变化会重建，只有变配才有费用差
+	if state != nil && plan.Engine.Equal(state.Engine) && !resp.Diagnostics.HasError() {
+		addDpsCostWarning(specs, plan.Engine.ValueString(), state.Size.ValueString(), plan.Size.ValueString(), path.Root("size"), &resp.Diagnostics)
+	}
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *dpsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
@@ -163,6 +285,14 @@ func (r *dpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !checkDeletionProtection(r.client, state.DeletionProtection, "dps", state.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
 
 	meta := common.RouteRegionUri(ctx, state.DwsuId.ValueString(), r.client, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -170,10 +300,7 @@ func (r *dpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 	regionUri := meta.URI
 
-	_, err := common.CommonRetry(ctx, func() (*string, error) {
-		err := r.client.DropDps(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
-		return nil, err
-	})
+	err := r.client.DropDps(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
 	if err != nil {
 		tflog.Error(ctx, "error delete dps "+err.Error())
 		resp.Diagnostics.AddError(
@@ -182,17 +309,16 @@ func (r *dpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		)
 		return
 	}
-	_, err = common.TimeOutTask(r.client.CheckTimeOut, r.client.CheckInterval, func() (any, error) {
-		dps, err2 := r.client.GetDps(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
-		if err2 != nil {
-			//这里判断是否要重试
-			return dps, err2
-		}
-		if dps == nil || dps.Status == client.DPS_STATUS_DROPPED {
-			return dps, nil
+	waiter := common.NewWaiter(r.client, deleteTimeout, func(ctx context.Context) (*client.DpsMode, string, error) {
+		dps, err := r.client.GetDps(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
+		if err != nil || dps == nil {
+			return nil, "", err
 		}
-		return dps, fmt.Errorf("wait delete dps timeout ")
+		return dps, dps.Status, nil
 	})
+	waiter.Target = []string{client.DPS_STATUS_DROPPED}
+	waiter.TargetNotFound = true
+	_, err = waiter.Wait(ctx)
 	if err != nil {
 		tflog.Error(ctx, "error wait dps delete "+err.Error())
 		resp.Diagnostics.AddError(
@@ -205,19 +331,16 @@ func (r *dpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 
 func (r *dpsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
-	idParts := strings.Split(req.ID, ",")
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: dwsu_id,dps_id. Got: %q", req.ID),
-		)
+	dwsuId, dpsId, err := common.ParseDpsImportId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
 		return
 	}
 	//校验dps状态
-	CheckDpsImport(ctx, r.client, idParts[0], idParts[1], &resp.Diagnostics)
+	CheckDpsImport(ctx, r.client, dwsuId, dpsId, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dwsu_id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dwsu_id"), dwsuId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dpsId)...)
 }
@@ -3,15 +3,104 @@ package resource
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"strings"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
 	"terraform-provider-relyt/internal/provider/model"
+	"time"
 )
 
-func updateDps(ctx context.Context, relytClient *client.RelytClient, state, plan *model.Dps, diag *diag.Diagnostics, dwsuId, dpsId string) {
+const (
+	dpsDesiredStateRunning   = "running"
+	dpsDesiredStateSuspended = "suspended"
+)
+
+// dpsEngines are the engines a dps can be created with.
+var dpsEngines = []string{client.DPS_ENGINE_HYBRID, client.DPS_ENGINE_EXTREME, client.DPS_ENGINE_VECTOR}
+
+// validateDpsSize checks the planned size against the specs of the engine in the edition and region of the dwsu,
+// and returns the specs. Nothing is checked while engine or size is unknown, or the specs can't be listed.
+func validateDpsSize(ctx context.Context, relytClient *client.RelytClient, edition, cloud, region string, dps *model.Dps, sizePath path.Path, diagnostics *diag.Diagnostics) []client.Spec {
+	if dps.Engine.IsUnknown() || dps.Engine.IsNull() || dps.Size.IsUnknown() || dps.Size.IsNull() {
+		return nil
+	}
+	specs, err := relytClient.ListSpec(ctx, edition, dps.Engine.ValueString(), cloud, region)
+	if err != nil {
+		// 规格目录不可用时不阻塞plan，错误的size仍会在apply时失败
+		tflog.Warn(ctx, "skip size validation, error list dps specs: "+err.Error())
+		diagnostics.AddAttributeWarning(sizePath, "can't validate size", "error list dps specs: "+err.Error())
+		return nil
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+	sizes := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		sizes = append(sizes, spec.Name)
+	}
+	if !slices.Contains(sizes, dps.Size.ValueString()) {
+		diagnostics.AddAttributeError(sizePath, "invalid size",
+			fmt.Sprintf("size %s isn't available for %s dps of edition %s in %s %s! valid sizes: %s",
+				dps.Size.ValueString(), dps.Engine.ValueString(), edition, cloud, region, strings.Join(sizes, ", ")))
+	}
+	return specs
+}
+
+// addDpsCostWarning summarises the usage rates of a resize as a plan warning, so the cost impact shows up in review.
+func addDpsCostWarning(specs []client.Spec, engine, oldSize, newSize string, sizePath path.Path, diagnostics *diag.Diagnostics) {
+	oldSpec := findSpec(specs, oldSize)
+	newSpec := findSpec(specs, newSize)
+	if oldSpec == nil || newSpec == nil {
+		return
+	}
+	oldRates := sumUsageRates(oldSpec.UsageRates)
+	newRates := sumUsageRates(newSpec.UsageRates)
+	rateTypes := make([]string, 0, len(newRates))
+	for rateType := range oldRates {
+		rateTypes = append(rateTypes, rateType)
+	}
+	for rateType := range newRates {
+		if _, ok := oldRates[rateType]; !ok {
+			rateTypes = append(rateTypes, rateType)
+		}
+	}
+	if len(rateTypes) == 0 {
+		return
+	}
+	slices.Sort(rateTypes)
+	lines := make([]string, 0, len(rateTypes))
+	for _, rateType := range rateTypes {
+		delta := newRates[rateType] - oldRates[rateType]
+		lines = append(lines, fmt.Sprintf("%s: %g -> %g (%+g)", rateType, oldRates[rateType], newRates[rateType], delta))
+	}
+	diagnostics.AddAttributeWarning(sizePath, "Estimated hourly cost change",
+		fmt.Sprintf("resize %s dps from %s to %s changes the hourly usage rates:\n%s", engine, oldSize, newSize, strings.Join(lines, "\n")))
+}
+
+func findSpec(specs []client.Spec, name string) *client.Spec {
+	for i := range specs {
+		if specs[i].Name == name {
+			return &specs[i]
+		}
+	}
+	return nil
+}
+
+func sumUsageRates(rates []client.UsageRates) map[string]float64 {
+	sum := map[string]float64{}
+	for _, rate := range rates {
+		sum[rate.Type] += rate.Amount
+	}
+	return sum
+}
+
+func updateDps(ctx context.Context, relytClient *client.RelytClient, state, plan *model.Dps, diag *diag.Diagnostics, dwsuId, dpsId string, timeout time.Duration) {
 	diagnostics := diag
 	meta := common.RouteRegionUri(ctx, dwsuId, relytClient, diagnostics)
 	if diagnostics.HasError() {
@@ -45,11 +134,16 @@ func updateDps(ctx context.Context, relytClient *client.RelytClient, state, plan
 	if diagnostics.HasError() {
 		return
 	}
-	WaitDpsReady(ctx, relytClient, regionUri, dwsuId, dpsId, diagnostics)
+	//暂停中的dps变配后仍是暂停状态，等READY会一直超时；恢复或暂停由后面的desired_state处理
+	target := client.DPS_STATUS_READY
+	if dps.Status == client.DPS_STATUS_SUSPENDED {
+		target = client.DPS_STATUS_SUSPENDED
+	}
+	waitDpsStatus(ctx, relytClient, regionUri, dwsuId, dpsId, target, timeout, diagnostics)
 	if diagnostics.HasError() {
 		return
 	}
-	state.Status = types.StringValue(client.DPS_STATUS_READY)
+	state.Status = types.StringValue(target)
 	//mapRelytDpsToTFModel(dps, state)
 	//更改成功，则将Size设置为目标Size
 	state.Size = plan.Size
@@ -61,9 +155,7 @@ func readDps(ctx context.Context, dwsuId, dpsId string, r *client.RelytClient, d
 		return nil
 	}
 	regionUri := meta.URI
-	dps, err := common.CommonRetry(ctx, func() (*client.DpsMode, error) {
-		return r.GetDps(ctx, regionUri, dwsuId, dpsId)
-	})
+	dps, err := r.GetDps(ctx, regionUri, dwsuId, dpsId)
 	//_, err := r.client.GetDps(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
 	if err != nil || dps == nil {
 		msg := "read dps get nil"
@@ -98,35 +190,90 @@ func mapRelytDpsToTFModel(dps *client.DpsMode, dpsModel *model.Dps) {
 		if dps.Description != "" {
 			dpsModel.Description = types.StringValue(dps.Description)
 		}
+		//desired_state跟随实际状态，在terraform外被恢复或暂停后，下次apply才能再次生效
+		//开启auto_suspend时暂停和恢复由服务端自动完成，不跟随，否则每次apply都会把dps恢复
+		autoSuspend := dps.EnableAutoSuspend != nil && *dps.EnableAutoSuspend
+		if !autoSuspend && !dpsModel.DesiredState.IsNull() && !dpsModel.DesiredState.IsUnknown() {
+			switch dps.Status {
+			case client.DPS_STATUS_READY:
+				dpsModel.DesiredState = types.StringValue(dpsDesiredStateRunning)
+			case client.DPS_STATUS_SUSPENDED:
+				dpsModel.DesiredState = types.StringValue(dpsDesiredStateSuspended)
+			}
+		}
 	}
 }
 
-func WaitDpsReady(ctx context.Context, relytClient *client.RelytClient, regionUri string, dwsuId, dpsId string, diagnostics *diag.Diagnostics) (*client.DpsMode, error) {
-	queryDpsMode, err := common.TimeOutTask(relytClient.CheckTimeOut, relytClient.CheckInterval, func() (any, error) {
-		dps, err2 := relytClient.GetDps(ctx, regionUri, dwsuId, dpsId)
-		if err2 != nil {
-			//这里判断是否要充实
-			return dps, err2
-		}
-		if dps != nil && dps.Status == client.DPS_STATUS_READY {
-			return dps, nil
+func WaitDpsReady(ctx context.Context, relytClient *client.RelytClient, regionUri string, dwsuId, dpsId string, timeout time.Duration, diagnostics *diag.Diagnostics) (*client.DpsMode, error) {
+	return waitDpsStatus(ctx, relytClient, regionUri, dwsuId, dpsId, client.DPS_STATUS_READY, timeout, diagnostics)
+}
+
+func waitDpsStatus(ctx context.Context, relytClient *client.RelytClient, regionUri string, dwsuId, dpsId, target string, timeout time.Duration, diagnostics *diag.Diagnostics) (*client.DpsMode, error) {
+	waiter := common.NewWaiter(relytClient, timeout, func(ctx context.Context) (*client.DpsMode, string, error) {
+		dps, err := relytClient.GetDps(ctx, regionUri, dwsuId, dpsId)
+		if err != nil || dps == nil {
+			return nil, "", err
 		}
-		return dps, fmt.Errorf("dps is not Ready")
+		return dps, dps.Status, nil
 	})
+	waiter.Target = []string{target}
+	waiter.Failed = []string{client.DPS_STATUS_DROPPED}
+	queryDpsMode, err := waiter.Wait(ctx)
 	if err != nil {
-		tflog.Error(ctx, "error wait dps ready"+err.Error())
+		tflog.Error(ctx, "error wait dps "+target+err.Error())
 		diagnostics.AddError(
-			"wait dps failed!", "error wait dps ready! "+err.Error(),
+			"wait dps failed!", "error wait dps "+target+"! "+err.Error(),
 		)
 		return nil, err
 		//fmt.Println(fmt.Sprintf("drop dwsu%s", err.Error()))
 	}
-	//上面已经判断了，这里其实不可能为nil
-	var convertType *client.DpsMode
-	if queryDpsMode != nil {
-		convertType = queryDpsMode.(*client.DpsMode)
+	return queryDpsMode, err
+}
+
+// applyDpsDesiredState suspends or resumes the dps when desired_state changes from state to plan, then
+// waits for the matching status. Read keeps desired_state of state in line with the observed status, and on
+// create state holds a null desired_state.
+func applyDpsDesiredState(ctx context.Context, relytClient *client.RelytClient, state, plan *model.Dps, diagnostics *diag.Diagnostics, dwsuId, dpsId string, timeout time.Duration) {
+	if plan.DesiredState.IsNull() || plan.DesiredState.IsUnknown() || plan.DesiredState.Equal(state.DesiredState) {
+		state.DesiredState = plan.DesiredState
+		return
+	}
+	meta := common.RouteRegionUri(ctx, dwsuId, relytClient, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	regionUri := meta.URI
+	dps, err := relytClient.GetDps(ctx, regionUri, dwsuId, dpsId)
+	if err != nil || dps == nil {
+		msg := "read dps get nil"
+		if err != nil {
+			msg = err.Error()
+		}
+		diagnostics.AddError("error read", "error read dps before change state!"+msg)
+		return
+	}
+	target := client.DPS_STATUS_READY
+	if plan.DesiredState.ValueString() == dpsDesiredStateSuspended {
+		target = client.DPS_STATUS_SUSPENDED
 	}
-	return convertType, err
+	if dps.Status != target {
+		if target == client.DPS_STATUS_SUSPENDED {
+			_, err = relytClient.SuspendDps(ctx, regionUri, dwsuId, dpsId)
+		} else {
+			_, err = relytClient.ResumeDps(ctx, regionUri, dwsuId, dpsId)
+		}
+		if err != nil {
+			tflog.Error(ctx, "error change dps state"+err.Error())
+			diagnostics.AddError("change dps state failed!", "error "+plan.DesiredState.ValueString()+" dps!"+err.Error())
+			return
+		}
+		dps, err = waitDpsStatus(ctx, relytClient, regionUri, dwsuId, dpsId, target, timeout, diagnostics)
+		if err != nil {
+			return
+		}
+	}
+	state.Status = types.StringValue(dps.Status)
+	state.DesiredState = plan.DesiredState
 }
 
 func CheckDpsImport(ctx context.Context, relytClient *client.RelytClient, dwsuId, dpsId string, diagnostics *diag.Diagnostics) {
@@ -136,9 +283,7 @@ func CheckDpsImport(ctx context.Context, relytClient *client.RelytClient, dwsuId
 		return
 	}
 	regionUri := meta.URI
-	dps, err := common.CommonRetry(ctx, func() (*client.DpsMode, error) {
-		return relytClient.GetDps(ctx, regionUri, dwsuId, dpsId)
-	})
+	dps, err := relytClient.GetDps(ctx, regionUri, dwsuId, dpsId)
 	if dps == nil || err != nil {
 		errMsg := "dps not found!"
 		if err != nil {
@@ -147,9 +292,92 @@ func CheckDpsImport(ctx context.Context, relytClient *client.RelytClient, dwsuId
 		diagnostics.AddError("error to import", "msg: "+errMsg)
 		return
 	}
-	if dps.Status != client.DPS_STATUS_READY {
-		diagnostics.AddError("can't import", "dps isn't ready!")
+	if dps.Status != client.DPS_STATUS_READY && dps.Status != client.DPS_STATUS_SUSPENDED {
+		diagnostics.AddError("can't import", "dps isn't ready or suspended!")
+		return
+	}
+
+}
+
+// updateDpsDescription patches the description when it changes from state to plan.
+func updateDpsDescription(ctx context.Context, relytClient *client.RelytClient, state, plan *model.Dps, diagnostics *diag.Diagnostics, dwsuId, dpsId string) {
+	if plan.Description.Equal(state.Description) {
 		return
 	}
+	meta := common.RouteRegionUri(ctx, dwsuId, relytClient, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	_, err := relytClient.PatchDpsDescription(ctx, meta.URI, dwsuId, dpsId, plan.Description.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "error update dps description"+err.Error())
+		diagnostics.AddError("update dps failed!", "error update dps description!"+err.Error())
+		return
+	}
+	state.Description = plan.Description
+}
 
+// fillDpsLifecycle copies the lifecycle settings known in plan into a create or patch request.
+// Only settings differing from state are copied, a nil state copies every known setting.
+func fillDpsLifecycle(plan, state *model.DpsLifecycle, dps *client.DpsMode) bool {
+	changed := false
+	known := func(planValue, stateValue attr.Value) bool {
+		if planValue.IsNull() || planValue.IsUnknown() {
+			return false
+		}
+		return state == nil || !planValue.Equal(stateValue)
+	}
+	var stateLifecycle model.DpsLifecycle
+	if state != nil {
+		stateLifecycle = *state
+	}
+	if known(plan.AutoSuspend, stateLifecycle.AutoSuspend) {
+		dps.EnableAutoSuspend = plan.AutoSuspend.ValueBoolPointer()
+		changed = true
+	}
+	if known(plan.AutoResume, stateLifecycle.AutoResume) {
+		dps.EnableAutoResume = plan.AutoResume.ValueBoolPointer()
+		changed = true
+	}
+	if known(plan.KeepAliveTime, stateLifecycle.KeepAliveTime) {
+		dps.KeepAliveTime = plan.KeepAliveTime.ValueInt64Pointer()
+		changed = true
+	}
+	if known(plan.AdaptiveQueryScaling, stateLifecycle.AdaptiveQueryScaling) {
+		dps.EnableAdaptiveQueryScaling = plan.AdaptiveQueryScaling.ValueBoolPointer()
+		changed = true
+	}
+	if known(plan.AqsSize, stateLifecycle.AqsSize) {
+		dps.AqsSpec = &client.AqsSpec{Name: plan.AqsSize.ValueString()}
+		changed = true
+	}
+	return changed
+}
+
+// updateDpsLifecycle patches the lifecycle settings changed between state and plan.
+func updateDpsLifecycle(ctx context.Context, relytClient *client.RelytClient, state, plan *model.DpsLifecycle, diagnostics *diag.Diagnostics, dwsuId, dpsId string) {
+	patchDps := client.DpsMode{}
+	if !fillDpsLifecycle(plan, state, &patchDps) {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, dwsuId, relytClient, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	_, err := relytClient.PatchDps(ctx, meta.URI, dwsuId, dpsId, patchDps)
+	if err != nil {
+		tflog.Error(ctx, "error update dps lifecycle"+err.Error())
+		diagnostics.AddError("update dps failed!", "error update dps lifecycle!"+err.Error())
+		return
+	}
+	dps, err := relytClient.GetDps(ctx, meta.URI, dwsuId, dpsId)
+	if err != nil || dps == nil {
+		msg := "read dps get nil"
+		if err != nil {
+			msg = err.Error()
+		}
+		diagnostics.AddError("error read", "error read dps after update!"+msg)
+		return
+	}
+	common.MapDpsLifecycle(dps, state)
 }
@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/model"
+	"testing"
+)
+
+func TestValidateDpsSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dwsu/edition/standard/dps/extreme/specs" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"msg":"ok","data":[{"id":1,"name":"small"},{"id":2,"name":"large"}]}`))
+	}))
+	defer server.Close()
+	relytClient, _ := client.NewRelytClient(client.RelytClientConfig{ApiHost: server.URL, ClientTimeout: 5})
+	ctx := context.Background()
+	dps := model.Dps{Engine: types.StringValue("extreme"), Size: types.StringValue("small")}
+
+	diagnostics := diag.Diagnostics{}
+	if specs := validateDpsSize(ctx, &relytClient, "standard", "aws", "us-east-1", &dps, path.Root("size"), &diagnostics); len(specs) != 2 || diagnostics.HasError() {
+		t.Errorf("size small should be valid, got %v", diagnostics)
+	}
+	dps.Size = types.StringValue("medium")
+	validateDpsSize(ctx, &relytClient, "standard", "aws", "us-east-1", &dps, path.Root("size"), &diagnostics)
+	if !diagnostics.HasError() || !strings.Contains(diagnostics.Errors()[0].Detail(), "small, large") {
+		t.Errorf("size medium should be rejected with valid sizes, got %v", diagnostics)
+	}
+
+	// a spec catalogue that can't be listed only warns
+	dps.Engine = types.StringValue("hybrid")
+	diagnostics = diag.Diagnostics{}
+	validateDpsSize(ctx, &relytClient, "standard", "aws", "us-east-1", &dps, path.Root("size"), &diagnostics)
+	if diagnostics.HasError() || diagnostics.WarningsCount() != 1 {
+		t.Errorf("list spec failure should be a warning, got %v", diagnostics)
+	}
+	diagnostics = diag.Diagnostics{}
+	dps.Size = types.StringUnknown()
+	if validateDpsSize(ctx, &relytClient, "standard", "aws", "us-east-1", &dps, path.Root("size"), &diagnostics) != nil || len(diagnostics) != 0 {
+		t.Errorf("unknown size shouldn't be validated")
+	}
+}
+
+func TestAddDpsCostWarning(t *testing.T) {
+	specs := []client.Spec{
+		{Name: "small", UsageRates: []client.UsageRates{{Amount: 1.5, Type: "dpu"}}},
+		{Name: "large", UsageRates: []client.UsageRates{{Amount: 3, Type: "dpu"}, {Amount: 0.5, Type: "storage"}}},
+	}
+	diagnostics := diag.Diagnostics{}
+	addDpsCostWarning(specs, "extreme", "small", "large", path.Root("size"), &diagnostics)
+	if diagnostics.WarningsCount() != 1 {
+		t.Fatalf("resize should warn the cost change, got %v", diagnostics)
+	}
+	detail := diagnostics.Warnings()[0].Detail()
+	if !strings.Contains(detail, "dpu: 1.5 -> 3 (+1.5)") || !strings.Contains(detail, "storage: 0 -> 0.5 (+0.5)") {
+		t.Errorf("unexpected cost detail: %s", detail)
+	}
+	diagnostics = diag.Diagnostics{}
+	addDpsCostWarning(specs, "extreme", "small", "unknown", path.Root("size"), &diagnostics)
+	if len(diagnostics) != 0 {
+		t.Errorf("unknown spec shouldn't warn, got %v", diagnostics)
+	}
+}
@@ -0,0 +1,129 @@
+package resource
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"math/big"
+	"regexp"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+	"time"
+)
+
+const (
+	passwordLowers         = "abcdefghijklmnopqrstuvwxyz"
+	passwordUppers         = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits         = "0123456789"
+	defaultPasswordLength  = 16
+	defaultPasswordSpecial = "!#$%&*()-_=+[]{}<>:?"
+)
+
+// passwordSpecialPattern limits special_characters to printable ascii, the password is generated byte by byte.
+var passwordSpecialPattern = regexp.MustCompile(`^[!-~]+$`)
+
+// generatePassword generates a password of length with at least one lower, upper, digit and special character,
+// which is what the password policy of relyt asks for.
+func generatePassword(length int, special string) (string, error) {
+	charsets := []string{passwordLowers, passwordUppers, passwordDigits, special}
+	if length < len(charsets) {
+		return "", fmt.Errorf("password length should be at least %d", len(charsets))
+	}
+	if !passwordSpecialPattern.MatchString(special) {
+		return "", fmt.Errorf("special characters should be printable ascii characters, got %q", special)
+	}
+	all := passwordLowers + passwordUppers + passwordDigits + special
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(charsets) {
+			charset = charsets[i]
+		}
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+	// 打乱顺序，必选字符不固定在开头
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, err
+	}
+	return charset[i.Int64()], nil
+}
+
+// passwordRotationDue tells whether the generated password of state should be regenerated for plan: a new dwuser or
+// the generator is newly enabled, its length, charset or keepers changed, or rotate_after has passed since the last rotation.
+func passwordRotationDue(plan, state *tfModel.DWUserModel, now time.Time, diagnostics *diag.Diagnostics) bool {
+	generator := plan.PasswordGenerator
+	if generator == nil {
+		return false
+	}
+	var rotateAfter time.Duration
+	if !generator.RotateAfter.IsNull() && !generator.RotateAfter.IsUnknown() {
+		d, err := time.ParseDuration(generator.RotateAfter.ValueString())
+		if err != nil || d <= 0 {
+			diagnostics.AddAttributeError(path.Root("password_generator").AtName("rotate_after"), "invalid rotate_after",
+				fmt.Sprintf("rotate_after should be a positive duration like 720h. Got: %q", generator.RotateAfter.ValueString()))
+			return false
+		}
+		rotateAfter = d
+	}
+	if state == nil || state.PasswordGenerator == nil || state.GeneratedPassword.IsNull() {
+		return true
+	}
+	old := state.PasswordGenerator
+	if !generator.Length.Equal(old.Length) || !generator.SpecialCharacters.Equal(old.SpecialCharacters) || !generator.Keepers.Equal(old.Keepers) {
+		return true
+	}
+	if rotateAfter > 0 {
+		rotatedAt, err := time.Parse(time.RFC3339, state.PasswordRotatedAt.ValueString())
+		return err != nil || !now.Before(rotatedAt.Add(rotateAfter))
+	}
+	return false
+}
+
+// planGeneratedPassword keeps the generated password of state unless a rotation is due, then leaves it unknown for
+// apply to generate a new one.
+func planGeneratedPassword(plan, state *tfModel.DWUserModel, diagnostics *diag.Diagnostics) {
+	if plan.PasswordGenerator == nil {
+		plan.GeneratedPassword = types.StringNull()
+		plan.PasswordRotatedAt = types.StringNull()
+		return
+	}
+	if passwordRotationDue(plan, state, time.Now(), diagnostics) {
+		plan.GeneratedPassword = types.StringUnknown()
+		plan.PasswordRotatedAt = types.StringUnknown()
+		return
+	}
+	plan.GeneratedPassword = state.GeneratedPassword
+	plan.PasswordRotatedAt = state.PasswordRotatedAt
+}
+
+// rotateGeneratedPassword generates the password the plan left unknown and records the rotation time.
+func rotateGeneratedPassword(plan *tfModel.DWUserModel, diagnostics *diag.Diagnostics) types.String {
+	if plan.PasswordGenerator == nil || !plan.GeneratedPassword.IsUnknown() {
+		return types.StringNull()
+	}
+	password, err := generatePassword(int(plan.PasswordGenerator.Length.ValueInt64()), plan.PasswordGenerator.SpecialCharacters.ValueString())
+	if err != nil {
+		diagnostics.AddError("Failed generate password", "error generate password: "+err.Error())
+		return types.StringNull()
+	}
+	plan.GeneratedPassword = types.StringValue(password)
+	plan.PasswordRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	return plan.GeneratedPassword
+}
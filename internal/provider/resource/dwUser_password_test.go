@@ -0,0 +1,82 @@
+package resource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+	"testing"
+	"time"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		password, err := generatePassword(8, "#")
+		if err != nil {
+			t.Fatalf("generate password: %v", err)
+		}
+		if len(password) != 8 || !strings.ContainsAny(password, passwordLowers) || !strings.ContainsAny(password, passwordUppers) ||
+			!strings.ContainsAny(password, passwordDigits) || !strings.Contains(password, "#") {
+			t.Errorf("password %q doesn't meet the policy", password)
+		}
+	}
+	if _, err := generatePassword(3, "#"); err == nil {
+		t.Errorf("too short password should be rejected")
+	}
+	if _, err := generatePassword(8, "#€"); err == nil {
+		t.Errorf("non ascii special characters should be rejected")
+	}
+}
+
+func TestPasswordRotationDue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	generator := func(rotateAfter string, keeper string) *tfModel.PasswordGenerator {
+		keepers, _ := types.MapValue(types.StringType, map[string]attr.Value{"k": types.StringValue(keeper)})
+		g := &tfModel.PasswordGenerator{Length: types.Int64Value(16), SpecialCharacters: types.StringValue("#"),
+			RotateAfter: types.StringNull(), Keepers: keepers}
+		if rotateAfter != "" {
+			g.RotateAfter = types.StringValue(rotateAfter)
+		}
+		return g
+	}
+	state := &tfModel.DWUserModel{PasswordGenerator: generator("", "a"), GeneratedPassword: types.StringValue("x"),
+		PasswordRotatedAt: types.StringValue(now.Add(-48 * time.Hour).Format(time.RFC3339))}
+	cases := []struct {
+		name  string
+		plan  *tfModel.PasswordGenerator
+		state *tfModel.DWUserModel
+		due   bool
+	}{
+		{"new dwuser", generator("", "a"), nil, true},
+		{"newly enabled", generator("", "a"), &tfModel.DWUserModel{}, true},
+		{"unchanged", generator("", "a"), state, false},
+		{"keepers changed", generator("", "b"), state, true},
+		{"not due yet", generator("72h", "a"), state, false},
+		{"due", generator("24h", "a"), state, true},
+	}
+	for _, c := range cases {
+		diagnostics := diag.Diagnostics{}
+		if due := passwordRotationDue(&tfModel.DWUserModel{PasswordGenerator: c.plan}, c.state, now, &diagnostics); due != c.due || diagnostics.HasError() {
+			t.Errorf("%s: expect due %t, got %t %v", c.name, c.due, due, diagnostics)
+		}
+	}
+	diagnostics := diag.Diagnostics{}
+	passwordRotationDue(&tfModel.DWUserModel{PasswordGenerator: generator("1 month", "a")}, state, now, &diagnostics)
+	if !diagnostics.HasError() {
+		t.Errorf("invalid rotate_after should be rejected")
+	}
+}
+
+func TestRotateGeneratedPassword(t *testing.T) {
+	plan := &tfModel.DWUserModel{PasswordGenerator: &tfModel.PasswordGenerator{Length: types.Int64Value(12), SpecialCharacters: types.StringValue("#")},
+		GeneratedPassword: types.StringUnknown(), PasswordRotatedAt: types.StringUnknown()}
+	diagnostics := diag.Diagnostics{}
+	password := rotateGeneratedPassword(plan, &diagnostics)
+	if diagnostics.HasError() || len(password.ValueString()) != 12 || !plan.GeneratedPassword.Equal(password) || plan.PasswordRotatedAt.IsUnknown() {
+		t.Errorf("unknown password should be generated, got %v %v", plan, diagnostics)
+	}
+	if !rotateGeneratedPassword(plan, &diagnostics).IsNull() {
+		t.Errorf("known password shouldn't be rotated")
+	}
+}
@@ -3,12 +3,18 @@ package resource
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"strings"
@@ -19,9 +25,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &dwUserResource{}
-	_ resource.ResourceWithConfigure   = &dwUserResource{}
-	_ resource.ResourceWithImportState = &dwUserResource{}
+	_ resource.Resource                     = &dwUserResource{}
+	_ resource.ResourceWithConfigure        = &dwUserResource{}
+	_ resource.ResourceWithImportState      = &dwUserResource{}
+	_ resource.ResourceWithConfigValidators = &dwUserResource{}
+	_ resource.ResourceWithModifyPlan       = &dwUserResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -45,17 +53,37 @@ func (r *dwUserResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"dwsu_id":                             schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
-			"id":                                  schema.StringAttribute{Computed: true, Description: "The ID of the DW user.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"account_name":                        schema.StringAttribute{Required: true, Description: "The name of the DW user, which is unique in the instance. The name is the email address."},
-			"account_password":                    schema.StringAttribute{Required: true, Description: "initPassword"},
-			"datalake_aws_lakeformation_role_arn": schema.StringAttribute{Optional: true, Description: "The ARN of the cross-account IAM role, optional."},
-			"async_query_result_location_prefix":  schema.StringAttribute{Optional: true, Description: "The prefix of the path to the S3 output location."},
+			"dwsu_id":                     schema.StringAttribute{Required: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"id":                          schema.StringAttribute{Computed: true, Description: "The ID of the DW user.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"account_name":                schema.StringAttribute{Required: true, Description: "The name of the DW user, which is unique in the instance. The name is the email address.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"account_password":            schema.StringAttribute{Optional: true, Sensitive: true, Description: "initPassword. It is stored in state, use account_password_wo to keep it out of state."},
+			"account_password_wo":         schema.StringAttribute{Optional: true, Sensitive: true, WriteOnly: true, Description: "initPassword, write-only and never stored in state. Requires Terraform 1.11 or later."},
+			"account_password_wo_version": schema.Int64Attribute{Optional: true, Description: "Changing it resets the password to account_password_wo.", Validators: []validator.Int64{int64validator.AlsoRequires(path.MatchRoot("account_password_wo"))}},
+			"password_generator": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Generate the password instead of account_password, and rotate it when rotate_after passes or keepers change.",
+				Attributes: map[string]schema.Attribute{
+					"length":             schema.Int64Attribute{Optional: true, Computed: true, Default: int64default.StaticInt64(defaultPasswordLength), Description: "The length of the password. Default 16.", Validators: []validator.Int64{int64validator.Between(8, 64)}},
+					"special_characters": schema.StringAttribute{Optional: true, Computed: true, Default: stringdefault.StaticString(defaultPasswordSpecial), Description: "The special characters the password is made of besides letters and digits. Only printable ascii characters are allowed.", Validators: []validator.String{stringvalidator.RegexMatches(passwordSpecialPattern, "should be printable ascii characters")}},
+					"rotate_after":       schema.StringAttribute{Optional: true, Description: "The duration after which the password is rotated on the next apply, like 720h."},
+					"keepers":            schema.MapAttribute{Optional: true, ElementType: types.StringType, Description: "Arbitrary values, changing any of them rotates the password."},
+				},
+			},
+			"generated_password":                       schema.StringAttribute{Computed: true, Sensitive: true, Description: "The password generated by password_generator."},
+			"password_rotated_at":                      schema.StringAttribute{Computed: true, Description: "The time the generated password was last rotated, in RFC3339."},
+			"datalake_aws_lakeformation_role_arn":      schema.StringAttribute{Optional: true, Description: "The ARN of the cross-account IAM role, optional."},
+			"async_query_result_location_prefix":       schema.StringAttribute{Optional: true, Description: "The prefix of the path to the S3 output location."},
 			"async_query_result_location_aws_role_arn": schema.StringAttribute{Optional: true, Description: "The ARN of the role to access the output location, optional."},
 		},
 	}
 }
 
+func (r *dwUserResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("account_password"), path.MatchRoot("account_password_wo"), path.MatchRoot("password_generator")),
+	}
+}
+
 // Create a new resource.
 func (r *dwUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from dwUserModel
@@ -70,8 +98,18 @@ func (r *dwUserResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	regionUri := meta.URI
+	password := dwUserModel.AccountPassword
+	if dwUserModel.PasswordGenerator != nil {
+		password = rotateGeneratedPassword(&dwUserModel, &resp.Diagnostics)
+	} else if password.IsNull() {
+		//write-only的值只在config里
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("account_password_wo"), &password)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	relytAccount := client.Account{
-		InitPassword: dwUserModel.AccountPassword.ValueString(),
+		InitPassword: password.ValueString(),
 		Name:         dwUserModel.AccountName.ValueString(),
 	}
 	// Create new order
@@ -125,9 +163,12 @@ func (r *dwUserResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	config, err := common.CommonRetry(ctx, func() (*client.AsyncResult, error) {
-		return r.client.GetAsyncAccountConfig(ctx, meta.URI, state.DwsuId.ValueString(), state.ID.ValueString())
-	})
+	config, err := r.client.GetAsyncAccountConfig(ctx, meta.URI, state.DwsuId.ValueString(), state.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "dwuser not found! remove from state: "+state.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error get dwuser asyncAccountConfig",
@@ -145,9 +186,7 @@ func (r *dwUserResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	}
 
-	lakeInfo, err := common.CommonRetry(ctx, func() (*client.LakeFormation, error) {
-		return r.client.GetLakeFormationConfig(ctx, meta.URI, state.DwsuId.ValueString(), state.ID.ValueString())
-	})
+	lakeInfo, err := r.client.GetLakeFormationConfig(ctx, meta.URI, state.DwsuId.ValueString(), state.ID.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -197,13 +236,28 @@ func (r *dwUserResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	regionUri := meta.URI
 
-	if stat.AccountPassword.ValueString() != plan.AccountPassword.ValueString() {
+	password := types.StringNull()
+	//import后state里没有密码，不知道原密码时只记录配置，不重置密码
+	passwordKnown := stat.AccountPassword.ValueString() != "" || stat.PasswordGenerator != nil || !stat.AccountPasswordWoVersion.IsNull()
+	if !plan.AccountPassword.IsNull() && passwordKnown && !plan.AccountPassword.Equal(stat.AccountPassword) {
+		password = plan.AccountPassword
+	}
+	if !plan.AccountPasswordWoVersion.Equal(stat.AccountPasswordWoVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("account_password_wo"), &password)...)
+	}
+	if generated := rotateGeneratedPassword(&plan, &resp.Diagnostics); !generated.IsNull() {
+		password = generated
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !password.IsNull() {
 		//resp.Diagnostics.AddError("not support", "can't update init password!")
-		_, err := common.CommonRetry(ctx, func() (*client.CommonRelytResponse[string], error) {
-			return r.client.PatchAccount(ctx, regionUri, plan.DwsuId.ValueString(), plan.ID.ValueString(), plan.AccountPassword.ValueString())
-		})
+		_, err := r.client.PatchAccount(ctx, regionUri, plan.DwsuId.ValueString(), plan.ID.ValueString(), password.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Failed update password", " patch password failed with:"+err.Error())
+			//密码没改成功，保留旧的state
+			resp.State.Set(ctx, &stat)
 			return
 		}
 	}
@@ -221,6 +275,32 @@ func (r *dwUserResource) Update(ctx context.Context, req resource.UpdateRequest,
 	return
 }
 
+// ModifyPlan keeps the generated password unless a rotation is due.
+func (r *dwUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan tfModel.DWUserModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state *tfModel.DWUserModel
+	if !req.State.Raw.IsNull() {
+		state = &tfModel.DWUserModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	planGeneratedPassword(&plan, state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("generated_password"), plan.GeneratedPassword)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_rotated_at"), plan.PasswordRotatedAt)...)
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *dwUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
@@ -238,11 +318,10 @@ func (r *dwUserResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	regionUri := meta.URI
 
 	// Delete existing account
-	_, err := common.CommonRetry(ctx, func() (*any, error) {
-		err := r.client.DropAccount(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
-		return nil, err
-	})
-	//err := r.client.DropAccount(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
+	err := r.client.DropAccount(ctx, regionUri, state.DwsuId.ValueString(), state.ID.ValueString())
+	if client.IsNotFound(err) {
+		return
+	}
 	if err != nil {
 		//要不要加error
 		resp.Diagnostics.AddError(
@@ -272,14 +351,8 @@ func (r *dwUserResource) ImportState(ctx context.Context, req resource.ImportSta
 		return
 	}
 	regionUri := meta.URI
-	account, err := common.CommonRetry(ctx, func() (*client.Account, error) {
-		account, err := r.client.GetAccount(ctx, regionUri, dwsuId, accountName)
-		if err != nil {
-			return nil, err
-		}
-		return account.Data, nil
-	})
-	if err != nil || account == nil {
+	account, err := r.client.GetAccount(ctx, regionUri, dwsuId, accountName)
+	if err != nil || account == nil || account.Data == nil {
 		msg := "account not found!"
 		if err != nil {
 			msg = err.Error()
@@ -291,8 +364,7 @@ func (r *dwUserResource) ImportState(ctx context.Context, req resource.ImportSta
 	resp.State.SetAttribute(ctx, path.Root("dwsu_id"), dwsuId)
 	resp.State.SetAttribute(ctx, path.Root("id"), accountName)
 	resp.State.SetAttribute(ctx, path.Root("account_name"), accountName)
-	//password，not show
-	resp.State.SetAttribute(ctx, path.Root("account_password"), types.StringValue(""))
+	//password无法读取，保持null，下次apply时只记录配置的密码
 
 }
 
@@ -313,9 +385,7 @@ func (r *dwUserResource) handleAccountConfig(ctx context.Context, dwUserModel *t
 	//	dwUserModel.AsyncQueryResultLocationAwsRoleArn = types.StringNull()
 	//}
 	if !dwUserModel.AsyncQueryResultLocationAwsRoleArn.IsNull() && !dwUserModel.AsyncQueryResultLocationPrefix.IsNull() {
-		_, err := common.CommonRetry[client.CommonRelytResponse[string]](ctx, func() (*client.CommonRelytResponse[string], error) {
-			return r.client.AsyncAccountConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString(), asyncResult)
-		})
+		_, err := r.client.AsyncAccountConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString(), asyncResult)
 		if err != nil {
 			diagnostics.AddError(
 				"Error config dwuser",
@@ -324,9 +394,7 @@ func (r *dwUserResource) handleAccountConfig(ctx context.Context, dwUserModel *t
 			//return
 		}
 	} else if dwUserModel.AsyncQueryResultLocationPrefix.IsNull() && dwUserModel.AsyncQueryResultLocationAwsRoleArn.IsNull() {
-		_, err := common.CommonRetry[client.CommonRelytResponse[string]](ctx, func() (*client.CommonRelytResponse[string], error) {
-			return r.client.DeleteAsyncAccountConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString())
-		})
+		_, err := r.client.DeleteAsyncAccountConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString())
 		if err != nil {
 			diagnostics.AddError(
 				"Error config dwuser",
@@ -345,9 +413,7 @@ func (r *dwUserResource) handleAccountConfig(ctx context.Context, dwUserModel *t
 		dwUserModel.DatalakeAwsLakeformationRoleArn = types.StringNull()
 	}
 	if !dwUserModel.DatalakeAwsLakeformationRoleArn.IsNull() {
-		_, err := common.CommonRetry[client.CommonRelytResponse[string]](ctx, func() (*client.CommonRelytResponse[string], error) {
-			return r.client.LakeFormationConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString(), lakeFormation)
-		})
+		_, err := r.client.LakeFormationConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString(), lakeFormation)
 		if err != nil {
 			diagnostics.AddError(
 				"Error config dwuser",
@@ -356,9 +422,7 @@ func (r *dwUserResource) handleAccountConfig(ctx context.Context, dwUserModel *t
 			//return
 		}
 	} else if dwUserModel.DatalakeAwsLakeformationRoleArn.IsNull() {
-		_, err := common.CommonRetry[client.CommonRelytResponse[string]](ctx, func() (*client.CommonRelytResponse[string], error) {
-			return r.client.DeleteLakeFormationConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString())
-		})
+		_, err := r.client.DeleteLakeFormationConfig(ctx, regionUri, dwUserModel.DwsuId.ValueString(), dwUserModel.ID.ValueString())
 		if err != nil {
 			diagnostics.AddError(
 				"Error config dwuser",
@@ -0,0 +1,23 @@
+package resource
+
+import (
+	"context"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"testing"
+)
+
+func TestDwUserResource_schema(t *testing.T) {
+	ctx := context.Background()
+	resp := &fwresource.SchemaResponse{}
+	NewdwUserResource().Schema(ctx, fwresource.SchemaRequest{}, resp)
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+	password := resp.Schema.Attributes["account_password"]
+	if !password.IsSensitive() || password.IsRequired() {
+		t.Errorf("account_password should be optional and sensitive")
+	}
+	if wo := resp.Schema.Attributes["account_password_wo"]; !wo.IsWriteOnly() || !wo.IsSensitive() {
+		t.Errorf("account_password_wo should be write-only and sensitive")
+	}
+}
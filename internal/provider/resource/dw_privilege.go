@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"slices"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+)
+
+const privilegeAll = "ALL"
+
+// objectPrivileges are the privileges can be granted on each object type, same as postgres.
+var objectPrivileges = map[string][]string{
+	client.OBJECT_TYPE_DATABASE: {"CREATE", "CONNECT", "TEMPORARY", privilegeAll},
+	client.OBJECT_TYPE_SCHEMA:   {"CREATE", "USAGE", privilegeAll},
+	client.OBJECT_TYPE_TABLE:    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", privilegeAll},
+}
+
+// validatePrivilegeObject checks schema and table are set exactly as object_type asks, and the privileges are
+// valid for it.
+func validatePrivilegeObject(objectType, schema, table string, privileges []string, diagnostics *diag.Diagnostics) {
+	allowed, ok := objectPrivileges[objectType]
+	if !ok {
+		// object_type本身由OneOf校验
+		return
+	}
+	needSchema := objectType != client.OBJECT_TYPE_DATABASE
+	needTable := objectType == client.OBJECT_TYPE_TABLE
+	if needSchema != (schema != "") {
+		diagnostics.AddAttributeError(path.Root("schema"), "invalid schema",
+			fmt.Sprintf("schema should %sbe set when object_type is %s", notIf(!needSchema), objectType))
+	}
+	if needTable != (table != "") {
+		diagnostics.AddAttributeError(path.Root("table"), "invalid table",
+			fmt.Sprintf("table should %sbe set when object_type is %s", notIf(!needTable), objectType))
+	}
+	for _, privilege := range privileges {
+		if !slices.Contains(allowed, privilege) {
+			diagnostics.AddAttributeError(path.Root("privileges"), "invalid privilege",
+				fmt.Sprintf("privilege %s can't be granted on %s, expect one of %s", privilege, objectType, strings.Join(allowed, ", ")))
+		}
+	}
+}
+
+func notIf(not bool) string {
+	if not {
+		return "not "
+	}
+	return ""
+}
+
+// diffPrivileges returns the privileges in plan but not in state and the ones in state but not in plan.
+func diffPrivileges(state, plan []string) (grant, revoke []string) {
+	for _, privilege := range plan {
+		if !slices.Contains(state, privilege) {
+			grant = append(grant, privilege)
+		}
+	}
+	for _, privilege := range state {
+		if !slices.Contains(plan, privilege) {
+			revoke = append(revoke, privilege)
+		}
+	}
+	return grant, revoke
+}
+
+// readPrivileges maps the privileges read from relyt onto the configured ones: ALL comes back expanded, so it's kept
+// as long as every privilege of the object type is still granted.
+func readPrivileges(objectType string, configured, remote []string) []string {
+	if !slices.Contains(configured, privilegeAll) || slices.Contains(remote, privilegeAll) {
+		return remote
+	}
+	for _, privilege := range objectPrivileges[objectType] {
+		if privilege != privilegeAll && !slices.Contains(remote, privilege) {
+			return remote
+		}
+	}
+	privileges := []string{privilegeAll}
+	for _, privilege := range configured {
+		if privilege != privilegeAll {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges
+}
+
+// privilegeObject builds the privilege request of the object the resource is granted on.
+func privilegeObject(privilege *tfModel.DwPrivilegeModel, privileges []string) client.Privilege {
+	return client.Privilege{
+		Grantee:    privilege.Grantee.ValueStringPointer(),
+		ObjectType: privilege.ObjectType.ValueStringPointer(),
+		Database:   privilege.Database.ValueStringPointer(),
+		Schema:     privilege.Schema.ValueStringPointer(),
+		Table:      privilege.Table.ValueStringPointer(),
+		Privileges: privileges,
+	}
+}
+
+// privilegeId joins grantee and the object path, which is also the import identifier.
+func privilegeId(privilege *tfModel.DwPrivilegeModel) string {
+	parts := []string{privilege.Grantee.ValueString(), privilege.Database.ValueString()}
+	if !privilege.Schema.IsNull() {
+		parts = append(parts, privilege.Schema.ValueString())
+	}
+	if !privilege.Table.IsNull() {
+		parts = append(parts, privilege.Table.ValueString())
+	}
+	return strings.Join(parts, ",")
+}
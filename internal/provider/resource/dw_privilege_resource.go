@@ -0,0 +1,256 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dwPrivilegeResource{}
+	_ resource.ResourceWithConfigure      = &dwPrivilegeResource{}
+	_ resource.ResourceWithImportState    = &dwPrivilegeResource{}
+	_ resource.ResourceWithValidateConfig = &dwPrivilegeResource{}
+)
+
+func NewDwPrivilegeResource() resource.Resource {
+	return &dwPrivilegeResource{}
+}
+
+// dwPrivilegeResource manages the privileges a user or role is granted on a database, schema or table.
+type dwPrivilegeResource struct {
+	RelytClientResource
+}
+
+// Metadata returns the resource type name.
+func (r *dwPrivilegeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dw_privilege"
+}
+
+// Schema defines the schema for the resource.
+func (r *dwPrivilegeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true, Description: "The ID of the privilege, in the format of grantee,database[,schema[,table]].", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"grantee": schema.StringAttribute{Required: true, Description: "The name of the DW user or role the privileges are granted to.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"object_type": schema.StringAttribute{Required: true, Description: "The type of the object the privileges are granted on. enum: {database, schema, table}",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{stringvalidator.OneOf(client.OBJECT_TYPE_DATABASE, client.OBJECT_TYPE_SCHEMA, client.OBJECT_TYPE_TABLE)}},
+			"database": schema.StringAttribute{Required: true, Description: "The name of the database.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"schema":   schema.StringAttribute{Optional: true, Description: "The name of the schema. Required when object_type is schema or table.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"table":    schema.StringAttribute{Optional: true, Description: "The name of the table. Required when object_type is table.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"privileges": schema.SetAttribute{Required: true, ElementType: types.StringType,
+				Description: "The privileges to grant, in upper case. database: CREATE, CONNECT, TEMPORARY; schema: CREATE, USAGE; table: SELECT, INSERT, UPDATE, DELETE, TRUNCATE, REFERENCES, TRIGGER. ALL grants all privileges of the object type.",
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)}},
+		},
+	}
+}
+
+// ValidateConfig checks schema and table against object_type, and the privileges can be granted on the object type.
+func (r *dwPrivilegeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config tfModel.DwPrivilegeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.ObjectType.IsUnknown() || config.Schema.IsUnknown() || config.Table.IsUnknown() || config.Privileges.IsUnknown() {
+		return
+	}
+	var elements []types.String
+	resp.Diagnostics.Append(config.Privileges.ElementsAs(ctx, &elements, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// 元素可能来自其他资源的输出，未知的元素留到plan时再校验
+	var privileges []string
+	for _, element := range elements {
+		if !element.IsUnknown() && !element.IsNull() {
+			privileges = append(privileges, element.ValueString())
+		}
+	}
+	validatePrivilegeObject(config.ObjectType.ValueString(), config.Schema.ValueString(), config.Table.ValueString(), privileges, &resp.Diagnostics)
+}
+
+// Create a new resource.
+func (r *dwPrivilegeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tfModel.DwPrivilegeModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dbClient := common.ParseAccessConfig(ctx, r.client, req.ProviderMeta, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var privileges []string
+	resp.Diagnostics.Append(plan.Privileges.ElementsAs(ctx, &privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	succ, err := dbClient.GrantPrivileges(ctx, privilegeObject(&plan, privileges))
+	if err != nil || !succ {
+		msg := "grant privileges not success"
+		if err != nil {
+			msg = err.Error()
+		}
+		resp.Diagnostics.AddError("Failed to grant privileges", "error grant privileges "+msg)
+		return
+	}
+	plan.ID = types.StringValue(privilegeId(&plan))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read resource information.
+func (r *dwPrivilegeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	dbClient := common.ParseAccessConfig(ctx, r.client, req.ProviderMeta, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state tfModel.DwPrivilegeModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	granted, err := dbClient.GetPrivileges(ctx, privilegeObject(&state, nil))
+	if client.IsNotFound(err) || (err == nil && (granted == nil || len(granted.Privileges) == 0)) {
+		tflog.Warn(ctx, "privileges not found! remove from state: "+state.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed read privileges", "error read privileges "+err.Error())
+		return
+	}
+	var configured []string
+	if !state.Privileges.IsNull() {
+		resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &configured, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	privileges, diags := types.SetValueFrom(ctx, types.StringType, readPrivileges(state.ObjectType.ValueString(), configured, granted.Privileges))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Privileges = privileges
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update grants the privileges added to and revokes the ones removed from privileges.
+func (r *dwPrivilegeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tfModel.DwPrivilegeModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dbClient := common.ParseAccessConfig(ctx, r.client, req.ProviderMeta, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var planPrivileges, statePrivileges []string
+	resp.Diagnostics.Append(plan.Privileges.ElementsAs(ctx, &planPrivileges, false)...)
+	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &statePrivileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	grant, revoke := diffPrivileges(statePrivileges, planPrivileges)
+	// 先revoke再grant，避免revoke ALL时把新授予的权限一起收回
+	if len(revoke) > 0 {
+		succ, err := dbClient.RevokePrivileges(ctx, privilegeObject(&plan, revoke))
+		if err != nil || !succ {
+			msg := "revoke privileges not success"
+			if err != nil {
+				msg = err.Error()
+			}
+			resp.Diagnostics.AddError("Failed to revoke privileges", "error revoke privileges "+msg)
+			return
+		}
+	}
+	if len(grant) > 0 {
+		succ, err := dbClient.GrantPrivileges(ctx, privilegeObject(&plan, grant))
+		if err != nil || !succ {
+			msg := "grant privileges not success"
+			if err != nil {
+				msg = err.Error()
+			}
+			resp.Diagnostics.AddError("Failed to grant privileges", "error grant privileges "+msg)
+			//revoke已成功，失败时也要写回state
+			remaining, _ := diffPrivileges(revoke, statePrivileges)
+			state.Privileges, _ = types.SetValueFrom(ctx, types.StringType, remaining)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dwPrivilegeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	dbClient := common.ParseAccessConfig(ctx, r.client, req.ProviderMeta, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state tfModel.DwPrivilegeModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var privileges []string
+	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	succ, err := dbClient.RevokePrivileges(ctx, privilegeObject(&state, privileges))
+	if client.IsNotFound(err) {
+		return
+	}
+	if err != nil || !succ {
+		msg := "revoke privileges not success"
+		if err != nil {
+			msg = err.Error()
+		}
+		resp.Diagnostics.AddError("Failed to revoke privileges", "error revoke privileges "+msg)
+	}
+}
+
+// ImportState takes grantee,database[,schema[,table]], the object type follows the number of parts.
+func (r *dwPrivilegeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+	if len(idParts) < 2 || len(idParts) > 4 || slices.Contains(idParts, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: grantee,database[,schema[,table]]. Got: %q", req.ID),
+		)
+		return
+	}
+	objectTypes := []string{client.OBJECT_TYPE_DATABASE, client.OBJECT_TYPE_SCHEMA, client.OBJECT_TYPE_TABLE}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grantee"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_type"), objectTypes[len(idParts)-2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), idParts[1])...)
+	if len(idParts) > 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema"), idParts[2])...)
+	}
+	if len(idParts) > 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table"), idParts[3])...)
+	}
+}
@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"slices"
+	"terraform-provider-relyt/internal/provider/client"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+	"testing"
+)
+
+func TestDwPrivilegeResources_schema(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range []func() fwresource.Resource{NewDwRoleResource, NewDwRoleGrantResource, NewDwPrivilegeResource} {
+		resp := &fwresource.SchemaResponse{}
+		newResource().Schema(ctx, fwresource.SchemaRequest{}, resp)
+		if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+			t.Fatalf("invalid schema: %v", diags)
+		}
+	}
+}
+
+func TestValidatePrivilegeObject(t *testing.T) {
+	cases := []struct {
+		objectType, schema, table string
+		privileges                []string
+		valid                     bool
+	}{
+		{client.OBJECT_TYPE_DATABASE, "", "", []string{"CONNECT", "CREATE"}, true},
+		{client.OBJECT_TYPE_DATABASE, "public", "", []string{"CONNECT"}, false},
+		{client.OBJECT_TYPE_SCHEMA, "public", "", []string{"USAGE"}, true},
+		{client.OBJECT_TYPE_SCHEMA, "", "", []string{"USAGE"}, false},
+		{client.OBJECT_TYPE_SCHEMA, "public", "", []string{"SELECT"}, false},
+		{client.OBJECT_TYPE_TABLE, "public", "orders", []string{"SELECT", "ALL"}, true},
+		{client.OBJECT_TYPE_TABLE, "public", "", []string{"SELECT"}, false},
+	}
+	for _, c := range cases {
+		diagnostics := diag.Diagnostics{}
+		validatePrivilegeObject(c.objectType, c.schema, c.table, c.privileges, &diagnostics)
+		if diagnostics.HasError() == c.valid {
+			t.Errorf("%+v: expect valid %t, got %v", c, c.valid, diagnostics)
+		}
+	}
+}
+
+func TestDwPrivilegeResource_validateConfigUnknownPrivilege(t *testing.T) {
+	ctx := context.Background()
+	r := NewDwPrivilegeResource().(*dwPrivilegeResource)
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	config := func(privileges ...tftypes.Value) tfsdk.Config {
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, nil),
+			"grantee":     tftypes.NewValue(tftypes.String, "analyst"),
+			"object_type": tftypes.NewValue(tftypes.String, client.OBJECT_TYPE_DATABASE),
+			"database":    tftypes.NewValue(tftypes.String, "sales"),
+			"schema":      tftypes.NewValue(tftypes.String, nil),
+			"table":       tftypes.NewValue(tftypes.String, nil),
+			"privileges":  tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, privileges),
+		})}
+	}
+	resp := &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: config(tftypes.NewValue(tftypes.String, "CONNECT"),
+		tftypes.NewValue(tftypes.String, tftypes.UnknownValue))}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("unknown privilege should be skipped, got %v", resp.Diagnostics)
+	}
+	resp = &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: config(tftypes.NewValue(tftypes.String, "SELECT"),
+		tftypes.NewValue(tftypes.String, tftypes.UnknownValue))}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("known privileges should still be validated")
+	}
+}
+
+func TestDiffPrivileges(t *testing.T) {
+	grant, revoke := diffPrivileges([]string{"SELECT", "INSERT"}, []string{"SELECT", "UPDATE"})
+	if !slices.Equal(grant, []string{"UPDATE"}) || !slices.Equal(revoke, []string{"INSERT"}) {
+		t.Errorf("unexpected diff: grant %v revoke %v", grant, revoke)
+	}
+}
+
+func TestReadPrivileges(t *testing.T) {
+	expanded := []string{"CREATE", "CONNECT", "TEMPORARY"}
+	if privileges := readPrivileges(client.OBJECT_TYPE_DATABASE, []string{"ALL"}, expanded); !slices.Equal(privileges, []string{"ALL"}) {
+		t.Errorf("expanded ALL should be kept as ALL, got %v", privileges)
+	}
+	if privileges := readPrivileges(client.OBJECT_TYPE_DATABASE, []string{"ALL"}, expanded[:2]); !slices.Equal(privileges, expanded[:2]) {
+		t.Errorf("partly revoked ALL should show the remaining privileges, got %v", privileges)
+	}
+	if privileges := readPrivileges(client.OBJECT_TYPE_DATABASE, []string{"CONNECT"}, expanded); !slices.Equal(privileges, expanded) {
+		t.Errorf("privileges granted out of terraform should show up, got %v", privileges)
+	}
+}
+
+func TestPrivilegeId(t *testing.T) {
+	privilege := tfModel.DwPrivilegeModel{
+		Grantee:  types.StringValue("analyst"),
+		Database: types.StringValue("sales"),
+		Schema:   types.StringValue("public"),
+		Table:    types.StringNull(),
+	}
+	if id := privilegeId(&privilege); id != "analyst,sales,public" {
+		t.Errorf("unexpected id: %s", id)
+	}
+}
@@ -0,0 +1,144 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &dwRoleGrantResource{}
+	_ resource.ResourceWithConfigure   = &dwRoleGrantResource{}
+	_ resource.ResourceWithImportState = &dwRoleGrantResource{}
+)
+
+func NewDwRoleGrantResource() resource.Resource {
+	return &dwRoleGrantResource{}
+}
+
+// dwRoleGrantResource grants a role to a dw user.
+type dwRoleGrantResource struct {
+	RelytClientResource
+}
+
+// Metadata returns the resource type name.
+func (r *dwRoleGrantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dw_role_grant"
+}
+
+// Schema defines the schema for the resource.
+func (r *dwRoleGrantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"dwsu_id":      schema.StringAttribute{Required: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"id":           schema.StringAttribute{Computed: true, Description: "The ID of the grant, in the format of role,account_name.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"role":         schema.StringAttribute{Required: true, Description: "The name of the role to grant.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"account_name": schema.StringAttribute{Required: true, Description: "The name of the DW user the role is granted to.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *dwRoleGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tfModel.DwRoleGrantModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, plan.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, err := r.client.GrantRole(ctx, meta.URI, plan.DwsuId.ValueString(), plan.AccountName.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to grant role", "Could not grant role, unexpected error: "+err.Error())
+		return
+	}
+	plan.ID = types.StringValue(plan.Role.ValueString() + "," + plan.AccountName.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read resource information.
+func (r *dwRoleGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tfModel.DwRoleGrantModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, state.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	roles, err := r.client.ListUserRoles(ctx, meta.URI, state.DwsuId.ValueString(), state.AccountName.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "dwuser not found! remove role grant from state: "+state.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed read role grant", "error list roles of dwuser "+err.Error())
+		return
+	}
+	for _, role := range roles {
+		if role != nil && role.Name == state.Role.ValueString() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	tflog.Warn(ctx, "role not granted! remove from state: "+state.ID.ValueString())
+	resp.State.RemoveResource(ctx)
+}
+
+// Update is never called, all attributes require replace.
+func (r *dwRoleGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan tfModel.DwRoleGrantModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dwRoleGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tfModel.DwRoleGrantModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, state.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.RevokeRole(ctx, meta.URI, state.DwsuId.ValueString(), state.AccountName.ValueString(), state.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error revoking role", "Could not revoke role, unexpected error: "+err.Error())
+	}
+}
+
+func (r *dwRoleGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: dwsu_id,role,account_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dwsu_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1]+","+idParts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_name"), idParts[2])...)
+}
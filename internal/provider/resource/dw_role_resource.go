@@ -0,0 +1,168 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"terraform-provider-relyt/internal/provider/client"
+	"terraform-provider-relyt/internal/provider/common"
+	tfModel "terraform-provider-relyt/internal/provider/model"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &dwRoleResource{}
+	_ resource.ResourceWithConfigure   = &dwRoleResource{}
+	_ resource.ResourceWithImportState = &dwRoleResource{}
+)
+
+func NewDwRoleResource() resource.Resource {
+	return &dwRoleResource{}
+}
+
+// dwRoleResource manages a role of the dw users in a dwsu.
+type dwRoleResource struct {
+	RelytClientResource
+}
+
+// Metadata returns the resource type name.
+func (r *dwRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dw_role"
+}
+
+// Schema defines the schema for the resource.
+func (r *dwRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"dwsu_id":          schema.StringAttribute{Required: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"id":               schema.StringAttribute{Computed: true, Description: "The ID of the role, same as name.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"name":             schema.StringAttribute{Required: true, Description: "The name of the role, which is unique in the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"description":      schema.StringAttribute{Optional: true, Description: "The description of the role."},
+			"create_timestamp": schema.Int64Attribute{Computed: true, Description: "The time the role was created, in unix milliseconds.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *dwRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tfModel.DwRoleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, plan.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, err := r.client.CreateRole(ctx, meta.URI, plan.DwsuId.ValueString(), client.Role{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create role", "Could not create role, unexpected error: "+err.Error())
+		return
+	}
+	plan.ID = plan.Name
+	plan.CreateTimestamp = types.Int64Null()
+	role, err := r.client.GetRole(ctx, meta.URI, plan.DwsuId.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		//角色已创建，读取失败只告警，下次refresh补齐
+		tflog.Warn(ctx, "error read role after create: "+err.Error())
+	} else if role != nil {
+		plan.CreateTimestamp = types.Int64Value(role.CreateTimestamp)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read resource information.
+func (r *dwRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tfModel.DwRoleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, state.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	role, err := r.client.GetRole(ctx, meta.URI, state.DwsuId.ValueString(), state.Name.ValueString())
+	if client.IsNotFound(err) || (err == nil && role == nil) {
+		tflog.Warn(ctx, "role not found! remove from state: "+state.Name.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed read role", "error read role "+err.Error())
+		return
+	}
+	// 未配置description且远端为空时保持null
+	if role.Description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(role.Description)
+	}
+	state.CreateTimestamp = types.Int64Value(role.CreateTimestamp)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *dwRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// dwsu_id and name require replace, only description is left to update
+	var plan, state tfModel.DwRoleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, plan.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Description.Equal(state.Description) {
+		_, err := r.client.PatchRole(ctx, meta.URI, plan.DwsuId.ValueString(), plan.Name.ValueString(), plan.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed update role", "patch role description failed with: "+err.Error())
+			return
+		}
+		state.Description = plan.Description
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dwRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tfModel.DwRoleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	meta := common.RouteRegionUri(ctx, state.DwsuId.ValueString(), r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.DropRole(ctx, meta.URI, state.DwsuId.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting role", "Could not delete role, unexpected error: "+err.Error())
+	}
+}
+
+func (r *dwRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: dwsu_id,name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dwsu_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[1])...)
+}
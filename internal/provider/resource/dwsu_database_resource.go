@@ -5,7 +5,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
 	"terraform-provider-relyt/internal/provider/model"
@@ -16,6 +19,7 @@ var (
 	_ resource.Resource                = &DwsuDatabaseResource{}
 	_ resource.ResourceWithConfigure   = &DwsuDatabaseResource{}
 	_ resource.ResourceWithImportState = &DwsuDatabaseResource{}
+	_ resource.ResourceWithModifyPlan  = &DwsuDatabaseResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -38,15 +42,16 @@ func (r *DwsuDatabaseResource) Schema(_ context.Context, _ resource.SchemaReques
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"name":  schema.StringAttribute{Required: true, Description: "The name of the database. The database name must not exceed 127 characters."},
-			"owner": schema.StringAttribute{Computed: true, Description: "The owner of the database."},
+			"name":                schema.StringAttribute{Required: true, Description: "The name of the database. The database name must not exceed 127 characters.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"owner":               schema.StringAttribute{Computed: true, Description: "The owner of the database."},
+			"deletion_protection": deletionProtectionAttribute("database"),
 		},
 	}
 }
 
 // Create a new resource.
 func (r *DwsuDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	database := model.DwsuDatabaseMeta{}
+	database := model.DwsuDatabaseModel{}
 	diags := req.Plan.Get(ctx, &database)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
@@ -65,6 +70,7 @@ func (r *DwsuDatabaseResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 	database.Owner = types.StringPointerValue(createDatabase.Owner)
+	database.DeletionProtection = readDeletionProtection(r.client, database.DeletionProtection)
 	resp.State.Set(ctx, database)
 }
 
@@ -74,12 +80,15 @@ func (r *DwsuDatabaseResource) Read(ctx context.Context, req resource.ReadReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	database := model.DwsuDatabaseMeta{}
+	database := model.DwsuDatabaseModel{}
 	diags := req.State.Get(ctx, &database)
 	resp.Diagnostics.Append(diags...)
-	getDatabase, err := common.CommonRetry(ctx, func() (*client.Database, error) {
-		return dbClient.GetDatabase(ctx, database.Name.ValueString())
-	})
+	getDatabase, err := dbClient.GetDatabase(ctx, database.Name.ValueString())
+	if client.IsNotFound(err) || (err == nil && getDatabase == nil) {
+		tflog.Warn(ctx, "database not found! remove from state: "+database.Name.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil || getDatabase == nil {
 		msg := " database not found!"
 		if err != nil {
@@ -89,12 +98,26 @@ func (r *DwsuDatabaseResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 	database.Owner = types.StringPointerValue(getDatabase.Owner)
+	database.DeletionProtection = readDeletionProtection(r.client, database.DeletionProtection)
 	resp.State.Set(ctx, &database)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *DwsuDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Not Support！", "database not support update! please rollback your change")
+	// name requires replace, only deletion_protection is left to update and it's kept in state only
+	var plan, state model.DwsuDatabaseModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.DeletionProtection = plan.DeletionProtection
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ModifyPlan fills the default deletion_protection.
+func (r *DwsuDatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, r.client, req, resp)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -103,13 +126,20 @@ func (r *DwsuDatabaseResource) Delete(ctx context.Context, req resource.DeleteRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	database := model.DwsuDatabaseMeta{}
+	database := model.DwsuDatabaseModel{}
 	diags := req.State.Get(ctx, &database)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !checkDeletionProtection(r.client, database.DeletionProtection, "database", database.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
 
-	getDatabase, err := common.CommonRetry(ctx, func() (*client.Database, error) {
-		return dbClient.GetDatabase(ctx, database.Name.ValueString())
-	})
+	getDatabase, err := dbClient.GetDatabase(ctx, database.Name.ValueString())
+	if client.IsNotFound(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Failed drop database", "error read database before drop! :"+err.Error())
 		return
@@ -118,14 +148,8 @@ func (r *DwsuDatabaseResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	succ, err := common.CommonRetry(ctx, func() (*bool, error) {
-		dropDatabase, err := dbClient.DropDatabase(ctx, database.Name.ValueString())
-		return &dropDatabase, err
-	})
-	if err != nil {
-		return
-	}
-	if err != nil || succ == nil || *succ == false {
+	succ, err := dbClient.DropDatabase(ctx, database.Name.ValueString())
+	if err != nil || succ == false {
 		msg := "database drop not success"
 		if err != nil {
 			msg = err.Error()
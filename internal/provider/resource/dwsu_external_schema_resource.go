@@ -7,8 +7,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"strings"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
@@ -43,10 +46,10 @@ func (r *DwsuExternalSchemaResource) Schema(_ context.Context, _ resource.Schema
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"name":         schema.StringAttribute{Required: true, Description: "The name of the external schema. The schema name must be consistent with the name of the target schema that exists in the external catalog.\nNote that the combined length of the catalog and schema values must not exceed 127 characters."},
-			"catalog":      schema.StringAttribute{Required: true, Description: "The name of the catalog.\nNote that the combined length of the catalog and schema values must not exceed 127 characters."},
-			"database":     schema.StringAttribute{Required: true, Description: "The name of the database."},
-			"table_format": schema.StringAttribute{Required: true, Description: "table_format", PlanModifiers: []planmodifier.String{modifier.GetStringIgnoreCaseModifier()}},
+			"name":         schema.StringAttribute{Required: true, Description: "The name of the external schema. The schema name must be consistent with the name of the target schema that exists in the external catalog.\nNote that the combined length of the catalog and schema values must not exceed 127 characters.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"catalog":      schema.StringAttribute{Required: true, Description: "The name of the catalog.\nNote that the combined length of the catalog and schema values must not exceed 127 characters.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"database":     schema.StringAttribute{Required: true, Description: "The name of the database.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"table_format": schema.StringAttribute{Required: true, Description: "table_format", PlanModifiers: []planmodifier.String{modifier.GetStringIgnoreCaseModifier(), stringplanmodifier.RequiresReplace()}},
 			//"table_format": schema.StringAttribute{Required: true, Description: "table_format"},
 			"properties": schema.MapAttribute{
 				ElementType: types.StringType,
@@ -58,7 +61,8 @@ func (r *DwsuExternalSchemaResource) Schema(_ context.Context, _ resource.Schema
 				//	"glue_region":              schema.StringAttribute{Computed: true, Optional: true, Description: "glue_region", Default: stringdefault.StaticString("ap-east-1")},
 				//	"s3_region":                schema.StringAttribute{Computed: true, Optional: true, Description: "s3_region", Default: stringdefault.StaticString("ap-east-1")},
 				//},
-				Description: "The properties of the schema."},
+				Description:   "The properties of the schema.",
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()}},
 		},
 	}
 }
@@ -104,9 +108,12 @@ func (r *DwsuExternalSchemaResource) Read(ctx context.Context, req resource.Read
 		Properties:  externalSchema.Properties,
 		TableFormat: externalSchema.TableFormat.ValueStringPointer(),
 	}
-	getExternalSchema, err := common.CommonRetry(ctx, func() (*client.SchemaMeta, error) {
-		return dbClient.GetExternalSchema(ctx, dbSchema)
-	})
+	getExternalSchema, err := dbClient.GetExternalSchema(ctx, dbSchema)
+	if client.IsNotFound(err) || (err == nil && getExternalSchema == nil) {
+		tflog.Warn(ctx, "external schema not found! remove from state: "+externalSchema.Name.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil || getExternalSchema == nil {
 		msg := " schema not found!"
 		if err != nil {
@@ -147,9 +154,10 @@ func (r *DwsuExternalSchemaResource) Delete(ctx context.Context, req resource.De
 		TableFormat: externalSchema.TableFormat.ValueStringPointer(),
 	}
 
-	getExternalSchema, err := common.CommonRetry(ctx, func() (*client.SchemaMeta, error) {
-		return dbClient.GetExternalSchema(ctx, dbSchema)
-	})
+	getExternalSchema, err := dbClient.GetExternalSchema(ctx, dbSchema)
+	if client.IsNotFound(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read schema", "error to read schema before drop! :"+err.Error())
 		return
@@ -158,11 +166,8 @@ func (r *DwsuExternalSchemaResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	succ, err := common.CommonRetry(ctx, func() (*bool, error) {
-		dropSchema, err := dbClient.DropSchema(ctx, dbSchema)
-		return &dropSchema, err
-	})
-	if err != nil || succ == nil || *succ != true {
+	succ, err := dbClient.DropSchema(ctx, dbSchema)
+	if err != nil || succ != true {
 		msg := "drop schema return false"
 		if err != nil {
 			msg = err.Error()
@@ -38,7 +38,7 @@ func (r *dwsuIntegrationInfoResource) Metadata(ctx context.Context, req resource
 func (r *dwsuIntegrationInfoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"dwsu_id": schema.StringAttribute{Required: true},
+			"dwsu_id": schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 			"integration_info": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "used to set Integration Info. Empty block will use the system default Integration Info ",
@@ -111,9 +111,7 @@ func (r *dwsuIntegrationInfoResource) Delete(ctx context.Context, req resource.D
 
 func (r *dwsuIntegrationInfoResource) readIntegrationInfo(ctx context.Context, regionUri string,
 	state *tfModel.IntegrationModel, diagnostics *diag.Diagnostics) {
-	info, err := common.CommonRetry(ctx, func() (*client.IntegrationInfo, error) {
-		return r.client.GetIntegration(ctx, regionUri, state.DwsuId.ValueString())
-	})
+	info, err := r.client.GetIntegration(ctx, regionUri, state.DwsuId.ValueString())
 	if err != nil || info == nil {
 		msg := " read info is nil"
 		if err != nil {
@@ -174,9 +172,7 @@ func (r *dwsuIntegrationInfoResource) updateIntegrationInfo(ctx context.Context,
 	integrationInfo := client.IntegrationInfo{
 		ExternalId: info.ExternalId.ValueString(),
 	}
-	_, err := common.CommonRetry(ctx, func() (*client.CommonRelytResponse[string], error) {
-		return r.client.PatchIntegration(ctx, regionUri, dwsuId, integrationInfo)
-	})
+	_, err := r.client.PatchIntegration(ctx, regionUri, dwsuId, integrationInfo)
 	if err != nil {
 		diagnostic.AddError("failed update integration info", "update integration info get err"+err.Error())
 		return
@@ -3,19 +3,23 @@ package resource
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
 	"terraform-provider-relyt/internal/provider/model"
+	"time"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -23,6 +27,7 @@ var (
 	_ resource.Resource                = &dwsuResource{}
 	_ resource.ResourceWithConfigure   = &dwsuResource{}
 	_ resource.ResourceWithImportState = &dwsuResource{}
+	_ resource.ResourceWithModifyPlan  = &dwsuResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -41,17 +46,24 @@ func (r *dwsuResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *dwsuResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *dwsuResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"id":      schema.StringAttribute{Computed: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"cloud":   schema.StringAttribute{Required: true, Description: "The ID of the cloud provider."},
-			"region":  schema.StringAttribute{Required: true, Description: "The ID of the region."},
-			"domain":  schema.StringAttribute{Required: true, Description: "The domain name of the service unit."},
-			"variant": schema.StringAttribute{Optional: true, Computed: true, Description: "The variables.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, Default: stringdefault.StaticString("basic")},
-			"edition": schema.StringAttribute{Optional: true, Computed: true, Description: "The ID of the edition.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, Default: stringdefault.StaticString("standard")},
-			"alias":   schema.StringAttribute{Optional: true, Description: "The alias of the service unit."},
+			"id":                  schema.StringAttribute{Computed: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"cloud":               schema.StringAttribute{Required: true, Description: "The ID of the cloud provider.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"region":              schema.StringAttribute{Required: true, Description: "The ID of the region.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"domain":              schema.StringAttribute{Required: true, Description: "The domain name of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"variant":             schema.StringAttribute{Optional: true, Computed: true, Description: "The variables.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, Default: stringdefault.StaticString("basic")},
+			"edition":             schema.StringAttribute{Optional: true, Computed: true, Description: "The ID of the edition.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, Default: stringdefault.StaticString("standard")},
+			"alias":               schema.StringAttribute{Optional: true, Description: "The alias of the service unit."},
+			"status":              schema.StringAttribute{Computed: true, Description: "The status of the service unit."},
+			"creator":             schema.StringAttribute{Computed: true, Description: "The email of the user who created the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"owner":               schema.StringAttribute{Computed: true, Description: "The email of the owner of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"create_timestamp":    schema.Int64Attribute{Computed: true, Description: "The time the service unit was created, in unix milliseconds.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
+			"update_timestamp":    schema.Int64Attribute{Computed: true, Description: "The time the service unit was last updated, in unix milliseconds."},
+			"tags":                schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "The tags of the service unit."},
+			"deletion_protection": deletionProtectionAttribute("service unit"),
 			//"last_updated": schema.Int64Attribute{Computed: true},
 			//"status":       schema.StringAttribute{Computed: true},
 			"default_dps": schema.SingleNestedAttribute{
@@ -59,32 +71,46 @@ func (r *dwsuResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Attributes: map[string]schema.Attribute{
 					//"dwsu_id":     schema.StringAttribute{Computed: true, Optional: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 					//"id":          schema.StringAttribute{Computed: true, Optional: true, Description: "The ID of the DPS cluster.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-					"name":        schema.StringAttribute{Required: true, Description: "The name of the DPS cluster."},
-					"description": schema.StringAttribute{Optional: true, Description: "The description of the DPS cluster."},
-					"engine":      schema.StringAttribute{Required: true, Description: "The type of the DPS cluster. hybrid, extreme, vector"},
-					"size":        schema.StringAttribute{Required: true, Description: "The name of the DPS cluster specification."},
-					"status":      schema.StringAttribute{Computed: true, Description: "The status of the DPS cluster."},
+					"name":          schema.StringAttribute{Required: true, Description: "The name of the DPS cluster."},
+					"description":   schema.StringAttribute{Optional: true, Description: "The description of the DPS cluster."},
+					"engine":        schema.StringAttribute{Required: true, Description: "The type of the DPS cluster. hybrid, extreme, vector", Validators: []validator.String{stringvalidator.OneOf(dpsEngines...)}},
+					"size":          schema.StringAttribute{Required: true, Description: "The name of the DPS cluster specification."},
+					"status":        schema.StringAttribute{Computed: true, Description: "The status of the DPS cluster."},
+					"desired_state": schema.StringAttribute{Optional: true, Description: "The state the DPS cluster is kept in. enum: {running, suspended}. Changing it resumes or suspends the cluster, unset leaves the cluster as it is. While auto_suspend is on, suspends and resumes done by the service are not reported as drift.", Validators: []validator.String{stringvalidator.OneOf(dpsDesiredStateRunning, dpsDesiredStateSuspended)}},
 				},
 			},
 			"endpoints": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "endpoints of dwsu",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"extensions": schema.MapAttribute{Computed: true,
-							ElementType: types.StringType,
-							Description: "extension info of endpoint"},
-						"host":     schema.StringAttribute{Computed: true, Description: "The name of the host used by the endpoint."},
-						"id":       schema.StringAttribute{Computed: true, Description: "The ID of the endpoint."},
-						"open":     schema.BoolAttribute{Computed: true, Description: "Public network access"},
-						"port":     schema.Int64Attribute{Computed: true, Description: "The port number used by the endpoint."},
-						"protocol": schema.StringAttribute{Computed: true, Description: "The protocol used by the endpoint. enum: {HTTP, HTTPS, JDBC}"},
-						"type":     schema.StringAttribute{Computed: true, Description: "The type of the endpoint. enum: {openapi, web_console, database}"},
-						"uri":      schema.StringAttribute{Computed: true, Description: "The URI of the endpoint."},
-					},
+					Attributes: endpointAttributes(),
 				},
 			},
+			"database_endpoint":      schema.SingleNestedAttribute{Computed: true, Description: "The database endpoint of the service unit.", Attributes: endpointAttributes()},
+			"openapi_endpoint":       schema.SingleNestedAttribute{Computed: true, Description: "The openapi endpoint of the service unit.", Attributes: endpointAttributes()},
+			"web_console_endpoint":   schema.SingleNestedAttribute{Computed: true, Description: "The web console endpoint of the service unit.", Attributes: endpointAttributes()},
+			"jdbc_url":               schema.StringAttribute{Computed: true, Description: "The JDBC url of the database endpoint."},
+			"psql_connection_string": schema.StringAttribute{Computed: true, Description: "The psql connection string of the database endpoint."},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+// endpointAttributes are the attributes of an endpoint, in the endpoints list and the endpoints keyed by type.
+func endpointAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extensions": schema.MapAttribute{Computed: true,
+			ElementType: types.StringType,
+			Description: "extension info of endpoint"},
+		"host":     schema.StringAttribute{Computed: true, Description: "The name of the host used by the endpoint."},
+		"id":       schema.StringAttribute{Computed: true, Description: "The ID of the endpoint."},
+		"open":     schema.BoolAttribute{Computed: true, Description: "Public network access"},
+		"port":     schema.Int64Attribute{Computed: true, Description: "The port number used by the endpoint."},
+		"protocol": schema.StringAttribute{Computed: true, Description: "The protocol used by the endpoint. enum: {HTTP, HTTPS, JDBC}"},
+		"type":     schema.StringAttribute{Computed: true, Description: "The type of the endpoint. enum: {openapi, web_console, database}"},
+		"uri":      schema.StringAttribute{Computed: true, Description: "The URI of the endpoint."},
 	}
 }
 
@@ -99,6 +125,11 @@ func (r *dwsuResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	createTimeout, diags := dwsuModel.Timeouts.Create(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	relytDwsu := client.DwsuModel{
 		DefaultDps: &client.DpsMode{
 			Description: dwsuModel.DefaultDps.Description.ValueString(),
@@ -119,7 +150,14 @@ func (r *dwsuResource) Create(ctx context.Context, req resource.CreateRequest, r
 			ID: dwsuModel.Region.ValueString(),
 		},
 	}
+	if !dwsuModel.Tags.IsNull() && !dwsuModel.Tags.IsUnknown() {
+		resp.Diagnostics.Append(dwsuModel.Tags.ElementsAs(ctx, &relytDwsu.Tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
+	dwsuModel.DeletionProtection = readDeletionProtection(r.client, dwsuModel.DeletionProtection)
 	if dwsuModel.ID.IsUnknown() {
 		//可重入
 		// Create dwsu
@@ -142,8 +180,8 @@ func (r *dwsuResource) Create(ctx context.Context, req resource.CreateRequest, r
 		dwsuModel.ID = types.StringValue(*createResult.Data)
 		resp.State.Set(ctx, dwsuModel)
 	}
-	queryDwsuModel, err := WaitDwsuReady(ctx, r.client, dwsuModel.ID.ValueString())
-	if err != nil || queryDwsuModel == nil {
+	relytQueryModel, err := WaitDwsuReady(ctx, r.client, dwsuModel.ID.ValueString(), createTimeout)
+	if err != nil || relytQueryModel == nil {
 		msg := "query dwsu failed! get null!"
 		if err != nil {
 			tflog.Error(ctx, "error wait dwsu ready"+err.Error())
@@ -153,13 +191,15 @@ func (r *dwsuResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 		//fmt.Println(fmt.Sprintf("drop dwsu%s", err.Error()))
 	}
-	relytQueryModel := queryDwsuModel.(*client.DwsuModel)
 	r.mapRelytModelToTerraform(ctx, &resp.Diagnostics, &dwsuModel, relytQueryModel)
 	tflog.Info(ctx, "bizId:"+relytQueryModel.ID)
 	readDps(ctx, dwsuModel.ID.ValueString(), dwsuModel.ID.ValueString(), r.client, &resp.Diagnostics, dwsuModel.DefaultDps)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	desired := *dwsuModel.DefaultDps
+	dwsuModel.DefaultDps.DesiredState = types.StringNull()
+	applyDpsDesiredState(ctx, r.client, dwsuModel.DefaultDps, &desired, &resp.Diagnostics, dwsuModel.ID.ValueString(), dwsuModel.ID.ValueString(), createTimeout)
 	//dwsuModel.LastUpdated = types.Int64Value(time.Now().UnixMilli())
 	//dwsuModel.Status = types.StringValue(relytQueryModel.Status)
 	// Set state to fully populated data
@@ -181,24 +221,34 @@ func (r *dwsuResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	relytQueryModel, err := common.CommonRetry(ctx, func() (*client.DwsuModel, error) {
-		return r.client.GetDwsu(ctx, state.ID.ValueString())
-	})
+	readTimeout, diags := state.Timeouts.Read(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	relytQueryModel, err := r.client.GetDwsu(ctx, state.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "dwsu not found! remove from state: "+state.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("error read dwsu", "msg: "+err.Error())
 		//tflog.Error(ctx, "error read dwsu"+err.Error())
 		return
 	}
-	if relytQueryModel == nil {
-		//	dwsu not found，throw error will cause refresh failed! block destroy. but warning will make import sucess
-		//
-		resp.Diagnostics = diag.Diagnostics{}
-		resp.Diagnostics.AddError("Skip Read", "DWSU not found!")
+	if relytQueryModel == nil || relytQueryModel.Status == client.DPS_STATUS_DROPPED {
+		//	dwsu not found，remove from state so that next plan will recreate it
+		tflog.Warn(ctx, "dwsu not found! remove from state: "+state.ID.ValueString())
+		resp.State.RemoveResource(ctx)
 		return
 	}
 	//state.Status = types.StringValue(dwsu.Status)
 	// Set refreshed state
 	r.mapRelytModelToTerraform(ctx, &resp.Diagnostics, &state, relytQueryModel)
+	state.DeletionProtection = readDeletionProtection(r.client, state.DeletionProtection)
 	readDps(ctx, state.ID.ValueString(), state.ID.ValueString(), r.client, &resp.Diagnostics, state.DefaultDps)
 	//if resp.Diagnostics.HasError() {
 	//	if relytQueryModel.Status != client.DPS_STATUS_READY {
@@ -223,18 +273,128 @@ func (r *dwsuResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	req.Plan.Get(ctx, &plan)
 	var state = model.DwsuModel{}
 	req.State.Get(ctx, &state)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	state.DeletionProtection = plan.DeletionProtection
+	r.patchDwsu(ctx, &state, &plan, &resp.Diagnostics)
+	//失败时也要写回state，否则框架会把plan当成新的state
+	resp.State.Set(ctx, &state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if plan.DefaultDps.Size != state.DefaultDps.Size {
-		updateDps(ctx, r.client, state.DefaultDps, plan.DefaultDps, &resp.Diagnostics, state.ID.ValueString(), state.ID.ValueString())
+		updateDps(ctx, r.client, state.DefaultDps, plan.DefaultDps, &resp.Diagnostics, state.ID.ValueString(), state.ID.ValueString(), updateTimeout)
 		//反馈给用户，当前dps状态
 		resp.State.Set(ctx, &state)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
+	updateDpsDescription(ctx, r.client, state.DefaultDps, plan.DefaultDps, &resp.Diagnostics, state.ID.ValueString(), state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
+		resp.State.Set(ctx, &state)
+		return
+	}
+	applyDpsDesiredState(ctx, r.client, state.DefaultDps, plan.DefaultDps, &resp.Diagnostics, state.ID.ValueString(), state.ID.ValueString(), updateTimeout)
 	resp.State.Set(ctx, &state)
 	return
 }
 
+// patchDwsu patches alias and tags when they change from state to plan.
+func (r *dwsuResource) patchDwsu(ctx context.Context, state, plan *model.DwsuModel, diagnostics *diag.Diagnostics) {
+	patch := client.DwsuPatch{}
+	if !plan.Alias.Equal(state.Alias) {
+		alias := plan.Alias.ValueString()
+		patch.Alias = &alias
+	}
+	if !plan.Tags.Equal(state.Tags) {
+		tags := []string{}
+		if !plan.Tags.IsNull() {
+			diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+			if diagnostics.HasError() {
+				return
+			}
+		}
+		patch.Tags = &tags
+	}
+	if patch.Alias == nil && patch.Tags == nil {
+		return
+	}
+	_, err := r.client.PatchDwsu(ctx, state.ID.ValueString(), patch)
+	if err != nil {
+		tflog.Error(ctx, "error update dwsu"+err.Error())
+		diagnostics.AddError("update dwsu failed!", "error update dwsu!"+err.Error())
+		return
+	}
+	state.Alias = plan.Alias
+	state.Tags = plan.Tags
+}
+
+// ModifyPlan fills the default deletion_protection, validates the default dps size, and rejects changes of edition,
+// variant and the default dps engine/name, they can neither be patched nor worth replacing a dwsu for.
+func (r *dwsuResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, r.client, req, resp)
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	var plan model.DwsuModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// 新建或者已经要重建（如改了cloud、region）时，按新建校验，不再拦截edition等的变更
+	if req.State.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		r.validateDefaultDps(ctx, &plan, nil, &resp.Diagnostics)
+		return
+	}
+	var state model.DwsuModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.validateDefaultDps(ctx, &plan, &state, &resp.Diagnostics)
+	if !plan.Edition.IsUnknown() && !plan.Edition.Equal(state.Edition) {
+		resp.Diagnostics.AddAttributeError(path.Root("edition"), "can't update edition",
+			"edition of dwsu can't be changed! now: "+state.Edition.ValueString())
+	}
+	if !plan.Variant.IsUnknown() && !plan.Variant.Equal(state.Variant) {
+		resp.Diagnostics.AddAttributeError(path.Root("variant"), "can't update variant",
+			"variant of dwsu can't be changed! now: "+state.Variant.ValueString())
+	}
+	if plan.DefaultDps != nil && state.DefaultDps != nil {
+		// default dps can only be resized, engine and name are patched nowhere
+		if !plan.DefaultDps.Engine.IsUnknown() && !plan.DefaultDps.Engine.Equal(state.DefaultDps.Engine) {
+			resp.Diagnostics.AddAttributeError(path.Root("default_dps").AtName("engine"), "can't update default dps engine",
+				"engine of default dps can't be changed! now: "+state.DefaultDps.Engine.ValueString())
+		}
+		if !plan.DefaultDps.Name.IsUnknown() && !plan.DefaultDps.Name.Equal(state.DefaultDps.Name) {
+			resp.Diagnostics.AddAttributeError(path.Root("default_dps").AtName("name"), "can't update default dps name",
+				"name of default dps can't be changed! now: "+state.DefaultDps.Name.ValueString())
+		}
+	}
+}
+
+// validateDefaultDps validates the default dps size of a new dwsu, or a resize of an existing one, and warns the
+// cost change of the resize.
+func (r *dwsuResource) validateDefaultDps(ctx context.Context, plan, state *model.DwsuModel, diagnostics *diag.Diagnostics) {
+	if r.client == nil || plan.DefaultDps == nil || plan.Edition.IsUnknown() || plan.Cloud.IsUnknown() || plan.Region.IsUnknown() {
+		return
+	}
+	if state != nil && state.DefaultDps != nil && plan.DefaultDps.Size.Equal(state.DefaultDps.Size) {
+		return
+	}
+	sizePath := path.Root("default_dps").AtName("size")
+	specs := validateDpsSize(ctx, r.client, plan.Edition.ValueString(), plan.Cloud.ValueString(), plan.Region.ValueString(),
+		plan.DefaultDps, sizePath, diagnostics)
+	if state != nil && state.DefaultDps != nil && !diagnostics.HasError() {
+		addDpsCostWarning(specs, plan.DefaultDps.Engine.ValueString(), state.DefaultDps.Size.ValueString(), plan.DefaultDps.Size.ValueString(), sizePath, diagnostics)
+	}
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *dwsuResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
@@ -244,6 +404,11 @@ func (r *dwsuResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if state.ID.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("id"),
@@ -251,9 +416,14 @@ func (r *dwsuResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 			"Can't drop dwsu with unknown id! Please check your status! ")
 		return
 	}
-	dwsu, err := common.CommonRetry(ctx, func() (*client.DwsuModel, error) {
-		return r.client.GetDwsu(ctx, state.ID.ValueString())
-	})
+	if !checkDeletionProtection(r.client, state.DeletionProtection, "dwsu", state.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+	dwsu, err := r.client.GetDwsu(ctx, state.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Info(ctx, "dwsu not found! treated as already deleted")
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get dwsu meta!", "Can't get dwsu info before drop it! err: "+err.Error())
 		return
@@ -265,10 +435,10 @@ func (r *dwsuResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	// Delete existing dwsu
-	_, err = common.CommonRetry(ctx, func() (*string, error) {
-		err = r.client.DropDwsu(ctx, state.ID.ValueString())
-		return nil, err
-	})
+	err = r.client.DropDwsu(ctx, state.ID.ValueString())
+	if client.IsNotFound(err) {
+		return
+	}
 	if err != nil {
 		//要不要加error
 		resp.Diagnostics.AddError(
@@ -278,17 +448,16 @@ func (r *dwsuResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 	//等待删除完成
-	_, err = common.TimeOutTask(r.client.CheckTimeOut, r.client.CheckInterval, func() (any, error) {
-		dwsu, err2 := r.client.GetDwsu(ctx, state.ID.ValueString())
-		if err2 != nil || dwsu == nil {
-			//这里判断是否要充实
-			return dwsu, err2
-		}
-		if dwsu == nil || dwsu.Status == client.DPS_STATUS_DROPPED {
-			return dwsu, nil
+	waiter := common.NewWaiter(r.client, deleteTimeout, func(ctx context.Context) (*client.DwsuModel, string, error) {
+		dwsu, err := r.client.GetDwsu(ctx, state.ID.ValueString())
+		if err != nil || dwsu == nil {
+			return nil, "", err
 		}
-		return dwsu, fmt.Errorf("wait delete dwsu timeout! ")
+		return dwsu, dwsu.Status, nil
 	})
+	waiter.Target = []string{client.DPS_STATUS_DROPPED}
+	waiter.TargetNotFound = true
+	_, err = waiter.Wait(ctx)
 	if err != nil {
 		tflog.Error(ctx, "error wait dwsu delete "+err.Error())
 		resp.Diagnostics.AddError(
@@ -330,9 +499,7 @@ func (r *dwsuResource) ImportState(ctx context.Context, req resource.ImportState
 		)
 		return
 	}
-	dwsu, err := common.CommonRetry(ctx, func() (*client.DwsuModel, error) {
-		return r.client.GetDwsu(ctx, req.ID)
-	})
+	dwsu, err := r.client.GetDwsu(ctx, req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError("error read dwsu", "msg: "+err.Error())
 		//tflog.Error(ctx, "error read dwsu"+err.Error())
@@ -357,51 +524,12 @@ func (r *dwsuResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 func (r *dwsuResource) mapRelytModelToTerraform(ctx context.Context, diagnostics *diag.Diagnostics, tfDwsuModel *model.DwsuModel, relytDwsuModel *client.DwsuModel) {
-	endpointsTFType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"extensions": types.MapType{
-				ElemType: types.StringType,
-			},
-			"host":     types.StringType,
-			"id":       types.StringType,
-			"open":     types.BoolType,
-			"port":     types.Int32Type,
-			"protocol": types.StringType,
-			"type":     types.StringType,
-			"uri":      types.StringType,
-		},
-	}
 	if relytDwsuModel != nil && tfDwsuModel != nil {
 		//tfDwsuModel.DefaultDps.DwsuId = types.StringValue(relytDwsuModel.ID)
 		//tfDwsuModel.DefaultDps.ID = types.StringValue(relytDwsuModel.ID)
-		var tfEndPoints []model.Endpoints
-		if relytDwsuModel.Endpoints != nil && len(relytDwsuModel.Endpoints) > 0 {
-			for _, endpoint := range relytDwsuModel.Endpoints {
-				tfEndpoint := model.Endpoints{
-					//Extensions: types.MapValue(types.StringType),
-					Host:       types.StringValue(endpoint.Host),
-					ID:         types.StringValue(endpoint.ID),
-					Open:       types.BoolValue(endpoint.Open),
-					Port:       types.Int32Value(endpoint.Port),
-					Protocol:   types.StringValue(endpoint.Protocol),
-					Type:       types.StringValue(endpoint.Type),
-					URI:        types.StringValue(endpoint.URI),
-					Extensions: types.MapNull(types.StringType),
-				}
-				//mapValue, diage := types.MapValueFrom(ctx, types.StringType, endpoint.Extensions)
-				//diagnostics.Append(diage...)
-				//tfEndpoint.Extensions = mapValue
-				tfEndPoints = append(tfEndPoints, tfEndpoint)
-				//tfDwsuModel.Endpoints = append(tfDwsuModel.Endpoints, tfEndpoint)
-			}
-			from, d := types.ListValueFrom(ctx, endpointsTFType, tfEndPoints)
-			diagnostics.Append(d...)
-			tfDwsuModel.Endpoints = from
-		} else {
-			from, d := types.ListValueFrom(ctx, endpointsTFType, tfEndPoints)
-			diagnostics.Append(d...)
-			tfDwsuModel.Endpoints = from
-		}
+		tfDwsuModel.Endpoints = common.MapEndpoints(ctx, relytDwsuModel.Endpoints, diagnostics)
+		common.MapDwsuAudit(relytDwsuModel, &tfDwsuModel.PlainDwsuModel)
+		r.mapConnection(ctx, diagnostics, tfDwsuModel, relytDwsuModel)
 
 		//only for import resource, fill property
 		//if tfDwsuModel.Region.IsNull() || tfDwsuModel.Region.IsUnknown() {
@@ -423,6 +551,16 @@ func (r *dwsuResource) mapRelytModelToTerraform(ctx context.Context, diagnostics
 		if relytDwsuModel.Alias != "" {
 			tfDwsuModel.Alias = types.StringValue(relytDwsuModel.Alias)
 		}
+		//没配置tags且远端也没有时保持null，避免和配置产生diff
+		if len(relytDwsuModel.Tags) > 0 || !tfDwsuModel.Tags.IsNull() {
+			remoteTags := relytDwsuModel.Tags
+			if remoteTags == nil {
+				remoteTags = []string{}
+			}
+			tags, d := types.SetValueFrom(ctx, types.StringType, remoteTags)
+			diagnostics.Append(d...)
+			tfDwsuModel.Tags = tags
+		}
 		if relytDwsuModel.Edition != nil {
 			tfDwsuModel.Edition = types.StringValue(relytDwsuModel.Edition.ID)
 		}
@@ -432,17 +570,28 @@ func (r *dwsuResource) mapRelytModelToTerraform(ctx context.Context, diagnostics
 	}
 }
 
-func WaitDwsuReady(ctx context.Context, relytClient *client.RelytClient, dpsId string) (any, error) {
-	queryDwsuModel, err := common.TimeOutTask(relytClient.CheckTimeOut, relytClient.CheckInterval, func() (any, error) {
-		dwsu, err2 := relytClient.GetDwsu(ctx, dpsId)
-		if err2 != nil {
-			//这里判断是否要重试
-			return dwsu, err2
-		}
-		if dwsu != nil && dwsu.Status == client.DPS_STATUS_READY {
-			return dwsu, nil
+// mapConnection fills the endpoints keyed by type and the connection strings of the database endpoint.
+func (r *dwsuResource) mapConnection(ctx context.Context, diagnostics *diag.Diagnostics, tfDwsuModel *model.DwsuModel, relytDwsuModel *client.DwsuModel) {
+	tfDwsuModel.DatabaseEndpoint = common.MapEndpointByType(ctx, relytDwsuModel.Endpoints, client.ENDPOINT_TYPE_DATABASE, diagnostics)
+	tfDwsuModel.OpenapiEndpoint = common.MapEndpointByType(ctx, relytDwsuModel.Endpoints, client.ENDPOINT_TYPE_OPENAPI, diagnostics)
+	tfDwsuModel.WebConsoleEndpoint = common.MapEndpointByType(ctx, relytDwsuModel.Endpoints, client.ENDPOINT_TYPE_WEB_CONSOLE, diagnostics)
+	tfDwsuModel.JdbcUrl = types.StringNull()
+	tfDwsuModel.PsqlConnectionString = types.StringNull()
+	if endpoint := common.EndpointByType(relytDwsuModel.Endpoints, client.ENDPOINT_TYPE_DATABASE); endpoint != nil {
+		tfDwsuModel.JdbcUrl = types.StringValue(common.JdbcUrl(endpoint, ""))
+		tfDwsuModel.PsqlConnectionString = types.StringValue(common.PsqlConnectionString(endpoint))
+	}
+}
+
+func WaitDwsuReady(ctx context.Context, relytClient *client.RelytClient, dwsuId string, timeout time.Duration) (*client.DwsuModel, error) {
+	waiter := common.NewWaiter(relytClient, timeout, func(ctx context.Context) (*client.DwsuModel, string, error) {
+		dwsu, err := relytClient.GetDwsu(ctx, dwsuId)
+		if err != nil || dwsu == nil {
+			return nil, "", err
 		}
-		return dwsu, fmt.Errorf("dwsu is not Ready")
+		return dwsu, dwsu.Status, nil
 	})
-	return queryDwsuModel, err
+	waiter.Target = []string{client.DPS_STATUS_READY}
+	waiter.Failed = []string{client.DPS_STATUS_DROPPED}
+	return waiter.Wait(ctx)
 }
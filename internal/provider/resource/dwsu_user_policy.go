@@ -6,7 +6,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-relyt/internal/provider/client"
 	"terraform-provider-relyt/internal/provider/common"
@@ -41,7 +43,7 @@ func (r *dwsuUserPolicy) Schema(_ context.Context, _ resource.SchemaRequest, res
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"dwsu_id":             schema.StringAttribute{Required: true, Description: "The ID of the service unit."},
+			"dwsu_id":             schema.StringAttribute{Required: true, Description: "The ID of the service unit.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 			"mfa":                 schema.StringAttribute{Optional: true, Computed: true, Default: stringdefault.StaticString("OPTIONAL"), Description: "The mfa policy of the dwsu user. Default 'OPTIONAL'"},
 			"reset_init_password": schema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(false), Description: "The choice whether user need to reset their init password. Default 'false'"},
 			//"mfa_protection_scopes": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "The mfa protection scopes."},
@@ -75,9 +77,7 @@ func (r *dwsuUserPolicy) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 	regionUri := meta.URI
 
-	clientPolicy, err := common.CommonRetry(ctx, func() (*client.UserSecurityPolicy, error) {
-		return r.client.GetUserSecurityPolicy(ctx, regionUri, securityPolicy.DwsuId.ValueString())
-	})
+	clientPolicy, err := r.client.GetUserSecurityPolicy(ctx, regionUri, securityPolicy.DwsuId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("error read user security policy!", "failed to read user security policy!"+err.Error())
 		return
@@ -131,9 +131,7 @@ func (r *dwsuUserPolicy) patchUserSecurityPolicy(ctx context.Context, securityPo
 	//	return
 	//}
 
-	_, err := common.CommonRetry(ctx, func() (*string, error) {
-		return r.client.PatchUserSecurityPolicy(ctx, regionUri, securityPolicy.DwsuId.ValueString(), policy)
-	})
+	_, err := r.client.PatchUserSecurityPolicy(ctx, regionUri, securityPolicy.DwsuId.ValueString(), policy)
 	if err != nil {
 		diag.AddError(
 			"Error patch dwsu user policy",
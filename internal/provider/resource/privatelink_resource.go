@@ -3,11 +3,14 @@ package resource
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"strings"
@@ -37,12 +40,12 @@ func (r *PrivateLinkResource) Metadata(_ context.Context, req resource.MetadataR
 }
 
 // Schema defines the schema for the resource.
-func (r *PrivateLinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *PrivateLinkResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Version: 0,
 		Attributes: map[string]schema.Attribute{
-			"dwsu_id":      schema.StringAttribute{Required: true, Description: "dwsuid"},
-			"service_type": schema.StringAttribute{Required: true, Description: "(database | data_api | web_console)"},
+			"dwsu_id":      schema.StringAttribute{Required: true, Description: "dwsuid", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"service_type": schema.StringAttribute{Required: true, Description: "(database | data_api | web_console)", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 			"service_name": schema.StringAttribute{Computed: true},
 			"status":       schema.StringAttribute{Computed: true},
 			"allow_principals": schema.ListNestedAttribute{
@@ -53,6 +56,9 @@ func (r *PrivateLinkResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 				Required: true, Description: "allow principal"},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
 	}
 }
 
@@ -66,6 +72,11 @@ func (r *PrivateLinkResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	createTimeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	dwsuId := plan.DwsuId.ValueString()
 	meta := common.RouteRegionUri(ctx, dwsuId, r.client, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -89,25 +100,19 @@ func (r *PrivateLinkResource) Create(ctx context.Context, req resource.CreateReq
 	plan.Status = types.StringValue(client.PRIVATE_LINK_UNKNOWN)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
-	privateLinkInfo, err := common.TimeOutTask(r.client.CheckTimeOut, r.client.CheckInterval, func() (any, error) {
-		linkService, errGet := r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, plan.ServiceType.ValueString())
-		if errGet != nil {
-			return nil, errGet
-		}
-		if linkService != nil && linkService.Status == client.PRIVATE_LINK_READY {
-			return linkService, nil
+	waiter := common.NewWaiter(r.client, createTimeout, func(ctx context.Context) (*client.PrivateLinkService, string, error) {
+		linkService, err := r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, plan.ServiceType.ValueString())
+		if err != nil || linkService == nil {
+			return nil, "", err
 		}
-		return linkService, fmt.Errorf("status not ready")
+		return linkService, linkService.Status, nil
 	})
+	waiter.Target = []string{client.PRIVATE_LINK_READY}
+	pl, err := waiter.Wait(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("wait ready failed!", "failed to wait privateLink! "+err.Error())
 		return
 	}
-	pl, ok := privateLinkInfo.(*client.PrivateLinkService)
-	if !ok {
-		resp.Diagnostics.AddError("return type not privatelink", "type convert error")
-		return
-	}
 	r.mapRelytToTFModel(nil, pl, &plan, &resp.Diagnostics)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -125,16 +130,26 @@ func (r *PrivateLinkResource) Read(ctx context.Context, req resource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	readTimeout, diags := state.Timeouts.Read(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 	dwsuId := state.DwsuId.ValueString()
 	meta := common.RouteRegionUri(ctx, dwsuId, r.client, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	regionUri := meta.URI
-	retry, err := common.CommonRetry(ctx, func() (*client.PrivateLinkService, error) {
-		return r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString())
-	})
-	if err != nil || retry == nil {
+	retry, err := r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString())
+	if client.IsNotFound(err) || (err == nil && retry == nil) {
+		tflog.Warn(ctx, "private link not found! remove from state: "+dwsuId+","+state.ServiceType.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
 		resp.Diagnostics.AddError("error get private link", "get private link failed!"+err.Error())
 		return
 	}
@@ -169,14 +184,13 @@ func (r *PrivateLinkResource) Update(ctx context.Context, req resource.UpdateReq
 	regionUri := meta.URI
 	service := client.PrivateLinkService{AllowedPrincipals: new([]string)}
 	r.parsePrinciple(ctx, plan.AllowPrincipals, &service)
-	_, err := common.CommonRetry(ctx, func() (*client.CommonRelytResponse[client.PrivateLinkService], error) {
-		return r.client.PatchPrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString(), service)
-	})
+	_, err := r.client.PatchPrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString(), service)
 	if err != nil {
 		resp.Diagnostics.AddError("error update private link", "update private link failed!"+err.Error())
 		return
 	}
 	state.AllowPrincipals = plan.AllowPrincipals
+	state.Timeouts = plan.Timeouts
 	resp.State.Set(ctx, &state)
 }
 
@@ -189,35 +203,36 @@ func (r *PrivateLinkResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, common.DefaultTimeout(r.client))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	dwsuId := state.DwsuId.ValueString()
 	meta := common.RouteRegionUri(ctx, dwsuId, r.client, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	regionUri := meta.URI
-	_, err := common.CommonRetry(ctx, func() (*client.CommonRelytResponse[string], error) {
-		return r.client.DeletePrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString())
-	})
+	_, err := r.client.DeletePrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString())
+	if client.IsNotFound(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("error delete private link", "delete private link failed!"+err.Error())
 		return
 	}
-	_, err = common.TimeOutTask(r.client.CheckTimeOut, r.client.CheckInterval, func() (any, error) {
-		linkService, errGet := r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString())
-		if errGet != nil {
-			return nil, errGet
+	waiter := common.NewWaiter(r.client, deleteTimeout, func(ctx context.Context) (*client.PrivateLinkService, string, error) {
+		linkService, err := r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, state.ServiceType.ValueString())
+		if err != nil || linkService == nil {
+			return nil, "", err
 		}
-		if linkService != nil {
-			return linkService, fmt.Errorf("still wait dropping")
-		}
-		return linkService, nil
+		return linkService, linkService.Status, nil
 	})
+	waiter.TargetNotFound = true
+	_, err = waiter.Wait(ctx)
 	if err != nil {
-		msg := "get privatelink still exist"
-		if err != nil {
-			msg = err.Error()
-		}
-		resp.Diagnostics.AddError("failed to wait delete", "failed to wait Delete"+msg)
+		resp.Diagnostics.AddError("failed to wait delete", "failed to wait Delete"+err.Error())
 		return
 	}
 }
@@ -240,9 +255,7 @@ func (r *PrivateLinkResource) ImportState(ctx context.Context, req resource.Impo
 		return
 	}
 	regionUri := meta.URI
-	privatelink, err := common.CommonRetry(ctx, func() (*client.PrivateLinkService, error) {
-		return r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, serviceType)
-	})
+	privatelink, err := r.client.GetPrivateLinkService(ctx, regionUri, dwsuId, serviceType)
 	if err != nil || privatelink == nil {
 		msg := "can't find private link!"
 		if err != nil {
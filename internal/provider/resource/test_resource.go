@@ -92,10 +92,16 @@ func (r *testResource) Create(ctx context.Context, req resource.CreateRequest, r
 	tflog.Info(ctx, "pass Diagnostics")
 	resp.State.Set(ctx, plan)
 
-	_, err := common.TimeOutTask(100000, 5, func() (any, error) {
-		time.Sleep(1)
-		return nil, fmt.Errorf("mock apiFail!")
-	})
+	waiter := &common.Waiter[string]{
+		Target:      []string{"READY"},
+		Timeout:     100000 * time.Second,
+		MinInterval: 5 * time.Second,
+		MaxInterval: 5 * time.Second,
+		Refresh: func(ctx context.Context) (*string, string, error) {
+			return nil, "", fmt.Errorf("mock apiFail!")
+		},
+	}
+	_, err := waiter.Wait(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("error wait!", err.Error())
 		return
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"log"
 	"terraform-provider-relyt/internal/provider"
-	"terraform-provider-relyt/internal/provider/common"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -32,8 +31,6 @@ var (
 )
 
 func main() {
-	common.RegSignalHandler()
-
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")